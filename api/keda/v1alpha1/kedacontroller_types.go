@@ -17,8 +17,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 )
 
@@ -27,17 +30,82 @@ type KedaControllerPhase string
 const (
 	PhaseNone             KedaControllerPhase = ""
 	PhaseInstallSucceeded KedaControllerPhase = "Installation Succeeded"
+	PhaseInProgress       KedaControllerPhase = "Installation In Progress"
 	PhaseIgnored          KedaControllerPhase = "Installation Ignored"
 	PhaseFailed           KedaControllerPhase = "Installation Failed"
 )
 
+// MonitoringMode is reported in KedaControllerStatus.MonitoringMode
+type MonitoringMode string
+
+const (
+	// MonitoringModePrometheusOperator means the ServiceMonitors and the PodMonitor are installed
+	MonitoringModePrometheusOperator MonitoringMode = "PrometheusOperator"
+	// MonitoringModeDisabled means monitoring is disabled in the spec
+	MonitoringModeDisabled MonitoringMode = "Disabled"
+	// MonitoringModeUnavailable means the Prometheus Operator CRDs are not present in the cluster
+	MonitoringModeUnavailable MonitoringMode = "Unavailable"
+)
+
+// Condition types reported in KedaControllerStatus.Conditions
+const (
+	// ConditionAvailable is True when every enabled KEDA component is installed and its workload is available
+	ConditionAvailable = "Available"
+	// ConditionProgressing is True while the KEDA workloads are rolling out
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is True when at least one KEDA component failed to install or to roll out
+	ConditionDegraded = "Degraded"
+
+	// ConditionOperatorReady reports the state of the KEDA Operator
+	ConditionOperatorReady = "OperatorReady"
+	// ConditionMetricsServerReady reports the state of the KEDA Metrics Server
+	ConditionMetricsServerReady = "MetricsServerReady"
+	// ConditionAdmissionWebhooksReady reports the state of the KEDA Admission Webhooks
+	ConditionAdmissionWebhooksReady = "AdmissionWebhooksReady"
+	// ConditionMonitoringReady reports the state of the monitoring resources
+	ConditionMonitoringReady = "MonitoringReady"
+	// ConditionOpenShiftMonitoringReady reports whether scalers can authenticate against the OpenShift Thanos Querier
+	ConditionOpenShiftMonitoringReady = "OpenShiftMonitoringReady"
+	// ConditionCertificatesReady reports whether the provider of the serving certificates issued them
+	ConditionCertificatesReady = "CertificatesReady"
+)
+
+// Reasons used in KedaControllerStatus.Conditions
+const (
+	ReasonInstallSucceeded = "InstallSucceeded"
+	ReasonInstallFailed    = "InstallFailed"
+	ReasonIgnored          = "Ignored"
+	ReasonDisabled         = "Disabled"
+
+	ReasonRolloutComplete   = "RolloutComplete"
+	ReasonRolloutInProgress = "RolloutInProgress"
+	ReasonRolloutFailed     = "RolloutFailed"
+)
+
 // KedaControllerSpec defines the desired state of KedaController
 // +kubebuilder:subresource:status
 type KedaControllerSpec struct {
 
+	// Single namespace watched by KEDA, all namespaces when neither this nor watchNamespaces or namespaceSelector is set
+	// Deprecated: use watchNamespaces
 	// +optional
 	WatchNamespace string `json:"watchNamespace,omitempty"`
 
+	// Namespaces watched by KEDA, they are merged with watchNamespace and the namespaces matching namespaceSelector
+	// +optional
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+
+	// Selector of the namespaces watched by KEDA, it is evaluated by the operator whenever namespaces or their labels change.
+	// When neither the selector matches a namespace nor other namespaces are listed, KEDA watches only its own namespace
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Registry mirror replacing the registry of the KEDA images, e.g. 'mirror.example.com' or
+	// 'mirror.example.com/ghcr' for disconnected clusters. It is applied to the default images and
+	// the images set by the operator environment variables, but not to images set in the components
+	// +optional
+	ImageRegistryMirror string `json:"imageRegistryMirror,omitempty"`
+
 	// +optional
 	Operator KedaOperatorSpec `json:"operator"`
 
@@ -50,9 +118,249 @@ type KedaControllerSpec struct {
 	// +optional
 	ServiceAccount KedaServiceAccountSpec `json:"serviceAccount"`
 
+	// Cloud workload identity of KEDA Operator, so that scalers authenticate to the cloud providers through
+	// the identity of its ServiceAccount instead of static secrets
+	// +optional
+	PodIdentity PodIdentitySpec `json:"podIdentity,omitempty"`
+
+	// Prometheus Operator monitoring of the KEDA components and of the operator itself
+	// +optional
+	Monitoring MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Authentication of the Prometheus scalers against Thanos Querier of the OpenShift cluster monitoring
+	// +optional
+	OpenShiftMonitoring OpenShiftMonitoringSpec `json:"openshiftMonitoring,omitempty"`
+
+	// Proxy used by the KEDA components to reach external endpoints, e.g. the cloud APIs called by scalers.
+	// On OpenShift the cluster-wide Proxy is used by default, the values set here override it
+	// +optional
+	Proxy ProxySpec `json:"proxy,omitempty"`
+
+	// TLS security profile of the servers of the KEDA components, i.e. the minimum TLS version and the cipher suites.
+	// On OpenShift the profile of the APIServer 'cluster' is used by default, elsewhere KEDA keeps its own defaults
+	// +optional
+	TLSProfile *configv1.TLSSecurityProfile `json:"tlsProfile,omitempty"`
+
+	// Provider of the serving certificates of the KEDA components
+	// +optional
+	Certificates CertificatesSpec `json:"certificates,omitempty"`
+
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
+// CertificatesProvider provides the serving certificates of the KEDA components
+// +kubebuilder:validation:Enum=openshift-service-ca;keda-builtin;cert-manager;user-provided
+type CertificatesProvider string
+
+const (
+	// CertificatesProviderOpenShiftServiceCA lets the OpenShift service CA operator issue the certificates
+	// and inject its CA into the APIService and the ValidatingWebhookConfiguration
+	CertificatesProviderOpenShiftServiceCA CertificatesProvider = "openshift-service-ca"
+	// CertificatesProviderKedaBuiltin lets KEDA Operator generate and rotate a self-signed CA and the certificates
+	CertificatesProviderKedaBuiltin CertificatesProvider = "keda-builtin"
+	// CertificatesProviderCertManager lets cert-manager issue the certificates and its cainjector inject the CA
+	CertificatesProviderCertManager CertificatesProvider = "cert-manager"
+	// CertificatesProviderUserProvided mounts the certificates of Secrets managed by the user and injects their CA bundle
+	CertificatesProviderUserProvided CertificatesProvider = "user-provided"
+)
+
+// CertificatesSpec configures how the serving certificates of the KEDA components are provided
+type CertificatesSpec struct {
+
+	// Provider of the certificates, either 'openshift-service-ca', 'keda-builtin', 'cert-manager' or 'user-provided'
+	// default value: openshift-service-ca on OpenShift, keda-builtin elsewhere
+	// +optional
+	Provider CertificatesProvider `json:"provider,omitempty"`
+
+	// cert-manager settings used with the 'cert-manager' provider
+	// +optional
+	CertManager CertManagerSpec `json:"certManager,omitempty"`
+
+	// Secrets with the certificates used with the 'user-provided' provider
+	// +optional
+	UserProvided UserProvidedCertificatesSpec `json:"userProvided,omitempty"`
+}
+
+// UserProvidedCertificatesSpec references the certificates issued out of band, i.e. Secrets in the namespace of the
+// KedaController with the PEM-encoded certificate and key in 'tls.crt' and 'tls.key', and the CA bundle which issued them.
+// The operator verifies them against the CA bundle and the names of the Services before rolling them out
+type UserProvidedCertificatesSpec struct {
+
+	// Secret with the serving certificate of KEDA Metrics Server, valid for 'keda-metrics-apiserver.<namespace>.svc'
+	// +optional
+	MetricsServerSecret string `json:"metricsServerSecret,omitempty"`
+
+	// Secret with the serving certificate of KEDA Admission Webhooks, valid for 'keda-admission-webhooks.<namespace>.svc'
+	// +optional
+	AdmissionWebhooksSecret string `json:"admissionWebhooksSecret,omitempty"`
+
+	// Secret with the certificate KEDA Operator serves gRPC with and KEDA Metrics Server authenticates to it with,
+	// which replaces kedaorg-certs and can't be named so. It has to be valid for client authentication and for
+	// 'keda-operator.<namespace>.svc.cluster.local', or the host of metricsServer.grpc
+	// +optional
+	GRPCSecret string `json:"grpcSecret,omitempty"`
+
+	// ConfigMap or Secret with the PEM-encoded CA bundle in 'ca.crt', it is injected into the APIService
+	// and the ValidatingWebhookConfiguration
+	// +optional
+	CABundle CASource `json:"caBundle,omitempty"`
+}
+
+// CertManagerSpec configures the cert-manager Certificate of the KEDA components
+type CertManagerSpec struct {
+
+	// Issuer or ClusterIssuer signing the certificate, by default the operator creates a self-signed CA
+	// and an Issuer of it in the namespace of the KedaController
+	// +optional
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+
+	// Requested lifetime of the certificate, e.g. '2160h'
+	// default value: 2160h, the cert-manager default
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before its expiration the certificate is renewed, e.g. '360h'
+	// default value: a third of the duration, the cert-manager default
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer
+type CertManagerIssuerRef struct {
+
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Kind of the issuer, either 'Issuer' in the namespace of the KedaController or 'ClusterIssuer'
+	// default value: Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// API group of the issuer, set it for external issuers
+	// default value: cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// ProxySpec configures the proxy environment variables and the trusted CA bundle of the KEDA components
+type ProxySpec struct {
+
+	// URL of the proxy for HTTP requests, rendered into HTTP_PROXY
+	// default value: status.httpProxy of the OpenShift cluster-wide Proxy
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// URL of the proxy for HTTPS requests, rendered into HTTPS_PROXY
+	// default value: status.httpsProxy of the OpenShift cluster-wide Proxy
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// Comma-separated hosts, domains and CIDRs which are not proxied, rendered into NO_PROXY
+	// default value: status.noProxy of the OpenShift cluster-wide Proxy
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// Name of a ConfigMap in the namespace of the KedaController holding the CA bundle trusted by KEDA Operator
+	// to reach the proxy. On OpenShift it defaults to the ConfigMap 'keda-trusted-ca-bundle', which the operator
+	// creates for the cluster network operator to inject the trusted CA bundle of the cluster into
+	// +optional
+	TrustedCAConfigMap string `json:"trustedCAConfigMap,omitempty"`
+
+	// The proxy is always injected into KEDA Operator, it is injected into the other components when enabled here
+	// +optional
+	Components ProxyComponentsSpec `json:"components,omitempty"`
+}
+
+// ProxyComponentsSpec selects the components the proxy is injected into besides KEDA Operator
+type ProxyComponentsSpec struct {
+
+	// Inject the proxy into KEDA Metrics Server
+	// default value: false
+	// +optional
+	MetricsServer bool `json:"metricsServer,omitempty"`
+
+	// Inject the proxy into KEDA Admission Webhooks
+	// default value: false
+	// +optional
+	AdmissionWebhooks bool `json:"admissionWebhooks,omitempty"`
+}
+
+// PodIdentitySpec sets up the workload identity of KEDA Operator for each cloud provider: the ServiceAccount annotations,
+// the projected ServiceAccount token and the environment variables the cloud SDKs read
+type PodIdentitySpec struct {
+
+	// AWS STS web identity, e.g. IAM roles for service accounts on EKS or STS on OpenShift
+	// +optional
+	AWS *AWSPodIdentitySpec `json:"aws,omitempty"`
+
+	// Azure Workload Identity
+	// +optional
+	AzureWorkload *AzureWorkloadPodIdentitySpec `json:"azureWorkload,omitempty"`
+
+	// GCP Workload Identity Federation
+	// +optional
+	GCP *GCPPodIdentitySpec `json:"gcp,omitempty"`
+}
+
+// AWSPodIdentitySpec configures the IAM role KEDA Operator assumes with its projected ServiceAccount token
+type AWSPodIdentitySpec struct {
+
+	// ARN of the IAM role, rendered into the ServiceAccount annotation 'eks.amazonaws.com/role-arn' and AWS_ROLE_ARN
+	RoleARN string `json:"roleArn"`
+
+	// Region of the STS endpoint, rendered into AWS_REGION
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Audience of the projected ServiceAccount token
+	// default value: sts.amazonaws.com
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// Name of a ClusterTriggerAuthentication the operator creates for the 'aws' pod identity provider, none when empty
+	// +optional
+	ClusterTriggerAuthentication string `json:"clusterTriggerAuthentication,omitempty"`
+}
+
+// AzureWorkloadPodIdentitySpec configures the Entra ID application KEDA Operator authenticates as with its
+// projected ServiceAccount token
+type AzureWorkloadPodIdentitySpec struct {
+
+	// Client ID of the application or user-assigned managed identity, rendered into the ServiceAccount
+	// annotation 'azure.workload.identity/client-id' and AZURE_CLIENT_ID
+	ClientID string `json:"clientId"`
+
+	// Tenant ID of the application, rendered into the ServiceAccount annotation 'azure.workload.identity/tenant-id'
+	// and AZURE_TENANT_ID
+	TenantID string `json:"tenantId"`
+
+	// Entra ID endpoint, rendered into AZURE_AUTHORITY_HOST
+	// default value: https://login.microsoftonline.com/
+	// +optional
+	AuthorityHost string `json:"authorityHost,omitempty"`
+
+	// Name of a ClusterTriggerAuthentication the operator creates for the 'azure-workload' pod identity provider, none when empty
+	// +optional
+	ClusterTriggerAuthentication string `json:"clusterTriggerAuthentication,omitempty"`
+}
+
+// GCPPodIdentitySpec configures the GCP service account KEDA Operator impersonates through a workload identity pool
+type GCPPodIdentitySpec struct {
+
+	// Email of the GCP service account, rendered into the ServiceAccount annotation 'iam.gke.io/gcp-service-account'
+	// and the credential configuration referenced by GOOGLE_APPLICATION_CREDENTIALS
+	ServiceAccountEmail string `json:"serviceAccountEmail"`
+
+	// Audience of the workload identity pool provider, e.g.
+	// '//iam.googleapis.com/projects/123456/locations/global/workloadIdentityPools/pool/providers/provider'
+	Audience string `json:"audience"`
+
+	// Name of a ClusterTriggerAuthentication the operator creates for the 'gcp' pod identity provider, none when empty
+	// +optional
+	ClusterTriggerAuthentication string `json:"clusterTriggerAuthentication,omitempty"`
+}
+
 type KedaServiceAccountSpec struct {
 
 	// Annotations applied to the Service Account
@@ -88,6 +396,10 @@ type KedaOperatorSpec struct {
 
 	GenericDeploymentSpec `json:",inline"`
 
+	GenericRuntimeSpec `json:",inline"`
+
+	TrustedCASpec `json:",inline"`
+
 	// Any user-defined arguments with possibility to override any existing or
 	// previously defined arguments. Allowed formats are '--argument=value',
 	// 'argument=value' or just 'value'. Ex.: '--v=0' or 'ENV_ARGUMENT'
@@ -100,10 +412,26 @@ type KedaOperatorSpec struct {
 	// data sources.
 	// +optional
 	CAConfigMaps []string `json:"caConfigMaps,omitempty"`
+
+	// Whether KEDA Operator generates and rotates the certificates of KEDA components itself,
+	// by default enabled with the 'keda-builtin' certificates provider only
+	// +optional
+	CertRotation *bool `json:"certRotation,omitempty"`
+
+	// Additional directories with PEM-encoded trusted certificate authorities (CAs) loaded
+	// by KEDA Operator, e.g. mounted from a volume. They are added after the directories of caConfigMaps
+	// +optional
+	CADirs []string `json:"caDirs,omitempty"`
 }
 
 type KedaMetricsServerSpec struct {
 
+	// Whether KEDA Metrics Server is installed, disabling it removes its objects including
+	// the v1beta1.external.metrics.k8s.io APIService, so that ScaledObjects can't be used
+	// default value: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Logging level for Metrics Server
 	// allowed values: "0" for info, "4" for debug, or an integer value greater than 0, specified as string
 	// default value: "0"
@@ -112,6 +440,21 @@ type KedaMetricsServerSpec struct {
 
 	GenericDeploymentSpec `json:",inline"`
 
+	GenericRuntimeSpec `json:",inline"`
+
+	TrustedCASpec `json:",inline"`
+
+	// Port KEDA Metrics Server serves the external metrics API on
+	// default value: 6443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SecurePort *int32 `json:"securePort,omitempty"`
+
+	// Settings of the gRPC connection from KEDA Metrics Server to KEDA Operator
+	// +optional
+	GRPC GRPCClientSpec `json:"grpc,omitempty"`
+
 	// Audit config for auditing log files. If a user wants to config other audit
 	// flags, he can do so manually with Args field.
 	// +optional
@@ -126,6 +469,12 @@ type KedaMetricsServerSpec struct {
 
 type KedaAdmissionWebhooksSpec struct {
 
+	// Whether KEDA Admission Webhooks are installed, disabling them removes their objects
+	// including the ValidatingWebhookConfiguration
+	// default value: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Logging level for Admission Webhooks
 	// allowed values: 'debug', 'info', 'error', or an integer value greater than 0, specified as string
 	// default value: info
@@ -146,6 +495,10 @@ type KedaAdmissionWebhooksSpec struct {
 
 	GenericDeploymentSpec `json:",inline"`
 
+	GenericRuntimeSpec `json:",inline"`
+
+	TrustedCASpec `json:",inline"`
+
 	// Any user-defined arguments with possibility to override any existing or
 	// previously defined arguments. Allowed formats are '--argument=value',
 	// 'argument=value' or just 'value'. Ex.: '--v=0' or 'ENV_ARGUMENT'
@@ -153,8 +506,52 @@ type KedaAdmissionWebhooksSpec struct {
 	Args []string `json:"args,omitempty"`
 }
 
+// IsEnabled returns whether KEDA Metrics Server is installed
+func (s KedaMetricsServerSpec) IsEnabled() bool {
+	return isEnabled(s.Enabled)
+}
+
+// IsEnabled returns whether KEDA Admission Webhooks are installed
+func (s KedaAdmissionWebhooksSpec) IsEnabled() bool {
+	return isEnabled(s.Enabled)
+}
+
+// isEnabled returns the value of an optional toggle which defaults to true
+func isEnabled(toggle *bool) bool {
+	return toggle == nil || *toggle
+}
+
 type GenericDeploymentSpec struct {
 
+	// Container image of the component, takes precedence over the image set by the operator
+	// environment variable (e.g. KEDA_OPERATOR_IMAGE) and the default image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Pull policy of the container image, defaults to Always
+	// https://kubernetes.io/docs/concepts/containers/images/#image-pull-policy
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Secrets used to pull the container image
+	// https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry/
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Number of replicas of the Deployment, defaults to 1. With more than one replica
+	// the operator creates a PodDisruptionBudget and, unless affinity or
+	// topologySpreadConstraints are set, spreads the pods across nodes and zones
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Override of the PodDisruptionBudget created when running more than one replica,
+	// by default at most one pod may be unavailable
+	// https://kubernetes.io/docs/tasks/run-application/configure-pdb/
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
 	// Annotations applied to the Deployment
 	// https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// +optional
@@ -190,6 +587,11 @@ type GenericDeploymentSpec struct {
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 
+	// Topology spread constraints for pod scheduling
+	// https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
 	// Pod priority
 	// https://kubernetes.io/docs/concepts/configuration/pod-priority-preemption/
 	// +optional
@@ -199,21 +601,463 @@ type GenericDeploymentSpec struct {
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Security context of the pod, the fields set here replace the fields of the default security context
+	// https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// Security context of the component container, the fields set here replace the fields of the default
+	// security context, e.g. to use a Localhost seccomp profile or to relax readOnlyRootFilesystem
+	// https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// Liveness probe of the component container, the fields set here replace the fields of the default probe,
+	// e.g. setting only initialDelaySeconds keeps the default handler
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// Readiness probe of the component container, the fields set here replace the fields of the default probe
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// Environment variables of the container, merged by name with the variables KEDA sets by default
+	// so that they can be overridden. Changes to the referenced ConfigMaps and Secrets roll out the Deployment
+	// https://kubernetes.io/docs/tasks/inject-data-application/define-environment-variable-container/
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Sources to populate environment variables of the container from, changes to the referenced
+	// ConfigMaps and Secrets roll out the Deployment
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/#configure-all-key-value-pairs-in-a-configmap-as-container-environment-variables
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Additional volumes of the pod, merged by name with the volumes of the component, which can't be replaced.
+	// The schema is not validated by the CRD to keep its size manageable, the Deployment validates the volumes
+	// https://kubernetes.io/docs/concepts/storage/volumes/
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Additional volume mounts of the component container, a mount is skipped when its mountPath
+	// is already used by the component
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Init containers run before the component container, merged by name with the init containers of the component
+	// https://kubernetes.io/docs/concepts/workloads/pods/init-containers/
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Sidecar containers run next to the component container, merged by name with the containers of the component
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// PodDisruptionBudgetSpec configures the PodDisruptionBudget of a KEDA component,
+// only one of minAvailable and maxUnavailable can be set
+// GenericRuntimeSpec holds the runtime settings tunable on every KEDA component, they are rendered
+// to the flags or environment variables supported by the installed KEDA version
+type GenericRuntimeSpec struct {
+
+	// Default timeout of the HTTP requests made by the component, e.g. by scalers
+	// default value: 3s
+	// +optional
+	HTTPTimeout *metav1.Duration `json:"httpTimeout,omitempty"`
+
+	// Maximum queries per second to the Kubernetes API server
+	// default value: 20
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KubeAPIQPS *int32 `json:"kubeAPIQPS,omitempty"`
+
+	// Maximum burst of queries to the Kubernetes API server
+	// default value: 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KubeAPIBurst *int32 `json:"kubeAPIBurst,omitempty"`
+
+	// Address the pprof profiling endpoint binds to, e.g. ':8082', profiling is disabled when empty
+	// +optional
+	ProfilingBindAddress string `json:"profilingBindAddress,omitempty"`
+}
+
+// TrustedCASpec configures the certificate authorities (CAs) a KEDA component trusts for outbound TLS connections,
+// e.g. to the data sources of scalers, in addition to the CAs of the system
+type TrustedCASpec struct {
+
+	// ConfigMaps and Secrets in the namespace of the KedaController with PEM-encoded trusted CAs,
+	// every key of them is loaded. A change of their data rolls out the component
+	// +optional
+	CASources []CASource `json:"caSources,omitempty"`
+
+	// Trust the CA bundle of the cluster, which OpenShift injects into ConfigMaps labeled
+	// 'config.openshift.io/inject-trusted-cabundle', including the CAs of the cluster-wide Proxy.
+	// It is ignored on other clusters
+	// default value: false
+	// +optional
+	ClusterTrustedCABundle bool `json:"clusterTrustedCABundle,omitempty"`
+}
+
+// CASource references a ConfigMap or a Secret with PEM-encoded trusted CAs, exactly one of them has to be set
+type CASource struct {
+
+	// Name of the ConfigMap
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Name of the Secret
+	// +optional
+	Secret string `json:"secret,omitempty"`
+}
+
+// GRPCClientSpec configures the gRPC connection KEDA Metrics Server fetches metrics from KEDA Operator with
+type GRPCClientSpec struct {
+
+	// Address of the KEDA Operator metrics service in the format 'host:port'
+	// default value: keda-operator.<namespace>.svc.cluster.local:9666
+	// +optional
+	MetricsServiceAddress string `json:"metricsServiceAddress,omitempty"`
+
+	// Authority used for the gRPC connection, e.g. when the server name of the
+	// KEDA Operator certificate doesn't match metricsServiceAddress
+	// +optional
+	MetricsServiceAuthority string `json:"metricsServiceAuthority,omitempty"`
+}
+
+type PodDisruptionBudgetSpec struct {
+
+	// Minimum number of pods that must stay available during a voluntary disruption
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Maximum number of pods that can be unavailable during a voluntary disruption
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// OpenShiftMonitoringSpec configures the ClusterTriggerAuthentication the Prometheus scalers use to query
+// Thanos Querier of the OpenShift cluster monitoring
+type OpenShiftMonitoringSpec struct {
+
+	// Create a ServiceAccount allowed to view the cluster monitoring and a ClusterTriggerAuthentication with
+	// its bound tokens, which KEDA requests and rotates, and the service CA bundle
+	// default value: false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Name of the ClusterTriggerAuthentication
+	// default value: keda-thanos-querier
+	// +optional
+	ClusterTriggerAuthentication string `json:"clusterTriggerAuthentication,omitempty"`
+}
+
+// MonitoringSpec configures the ServiceMonitors and the PodMonitor installed when the Prometheus Operator CRDs are present
+type MonitoringSpec struct {
+
+	// Whether the ServiceMonitors and the PodMonitor are installed
+	// default value: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Labels added to the ServiceMonitors and the PodMonitor, e.g. to match the serviceMonitorSelector of a Prometheus
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+
+	// Interval at which the metrics are scraped, e.g. '30s'
+	// default value: 60s
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Timeout of a scrape, it must not be greater than interval
+	// default value: the scrape timeout of the Prometheus
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// Relabelings applied to the scraped targets before ingestion
+	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config
+	// +optional
+	Relabelings []RelabelConfig `json:"relabelings,omitempty"`
+
+	// TLS configuration of the scrapes, setting it scrapes all endpoints over HTTPS
+	// +optional
+	TLSConfig *MonitoringTLSConfig `json:"tlsConfig,omitempty"`
+
+	// Key of a Secret in the namespace of the KedaController with the bearer token sent with the scrapes
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+
+	// Which components are monitored, all of them by default
+	// +optional
+	Components MonitoringComponentsSpec `json:"components,omitempty"`
+
+	// Alerts on the health of KEDA rendered into a PrometheusRule, it is only installed
+	// when the PrometheusRule CRD is present in the cluster
+	// +optional
+	Alerts AlertsSpec `json:"alerts,omitempty"`
+
+	// Grafana dashboard of KEDA Operator and KEDA Metrics Server stored in a ConfigMap, on OpenShift
+	// it is shown in the console under Observe -> Dashboards
+	// +optional
+	Dashboards DashboardsSpec `json:"dashboards,omitempty"`
+}
+
+// IsEnabled returns whether the ServiceMonitors and the PodMonitor are installed
+func (s MonitoringSpec) IsEnabled() bool {
+	return isEnabled(s.Enabled)
+}
+
+// MonitoringTLSConfig configures TLS of the scrapes, the referenced ConfigMaps and Secrets have to be
+// in the namespace of the KedaController
+type MonitoringTLSConfig struct {
+
+	// Key of a ConfigMap or Secret with the CA bundle the serving certificates are verified against
+	// +optional
+	CA *MonitoringKeySelector `json:"ca,omitempty"`
+
+	// Key of a ConfigMap or Secret with the client certificate, it requires keySecret
+	// +optional
+	Cert *MonitoringKeySelector `json:"cert,omitempty"`
+
+	// Key of a Secret with the private key of the client certificate, it requires cert
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+
+	// Name the serving certificates are verified against
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Skips the verification of the serving certificates
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// MonitoringKeySelector references a key of a ConfigMap or of a Secret, exactly one of them has to be set
+type MonitoringKeySelector struct {
+
+	// Key of a ConfigMap
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// Key of a Secret
+	// +optional
+	Secret *corev1.SecretKeySelector `json:"secret,omitempty"`
+}
+
+// MonitoringComponentsSpec toggles the monitoring of single components, unset toggles default to true
+type MonitoringComponentsSpec struct {
+
+	// ServiceMonitor of KEDA Operator
+	// +optional
+	Operator *bool `json:"operator,omitempty"`
+
+	// ServiceMonitor of KEDA Metrics Server
+	// +optional
+	MetricsServer *bool `json:"metricsServer,omitempty"`
+
+	// ServiceMonitor of KEDA Admission Webhooks
+	// +optional
+	AdmissionWebhooks *bool `json:"admissionWebhooks,omitempty"`
+
+	// PodMonitor of the KEDA OLM operator itself
+	// +optional
+	OLMOperator *bool `json:"olmOperator,omitempty"`
+}
+
+// IsOperatorEnabled returns whether the ServiceMonitor of KEDA Operator is installed
+func (s MonitoringComponentsSpec) IsOperatorEnabled() bool {
+	return isEnabled(s.Operator)
+}
+
+// IsMetricsServerEnabled returns whether the ServiceMonitor of KEDA Metrics Server is installed
+func (s MonitoringComponentsSpec) IsMetricsServerEnabled() bool {
+	return isEnabled(s.MetricsServer)
+}
+
+// IsAdmissionWebhooksEnabled returns whether the ServiceMonitor of KEDA Admission Webhooks is installed
+func (s MonitoringComponentsSpec) IsAdmissionWebhooksEnabled() bool {
+	return isEnabled(s.AdmissionWebhooks)
+}
+
+// IsOLMOperatorEnabled returns whether the PodMonitor of the KEDA OLM operator is installed
+func (s MonitoringComponentsSpec) IsOLMOperatorEnabled() bool {
+	return isEnabled(s.OLMOperator)
+}
+
+// AlertsSpec configures the alerts of the PrometheusRule
+type AlertsSpec struct {
+
+	// Whether the PrometheusRule with the alerts is installed
+	// default value: false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels added to every alert, e.g. to route them in Alertmanager
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+
+	// KedaMetricsAPIServiceUnavailable fires when the v1beta1.external.metrics.k8s.io APIService
+	// is unavailable, the threshold is not used
+	// default values: for 5m, severity critical
+	// +optional
+	MetricsAPIServiceUnavailable AlertSpec `json:"metricsAPIServiceUnavailable,omitempty"`
+
+	// KedaScalerErrors fires when the errors of a scaler within 5 minutes exceed the threshold
+	// default values: threshold 10, for 15m, severity warning
+	// +optional
+	ScalerErrors AlertSpec `json:"scalerErrors,omitempty"`
+
+	// KedaScaledObjectErrors fires when the errors of a ScaledObject within 5 minutes exceed the threshold,
+	// a ScaledObject which keeps failing is not Ready
+	// default values: threshold 0, for 15m, severity warning
+	// +optional
+	ScaledObjectErrors AlertSpec `json:"scaledObjectErrors,omitempty"`
+
+	// KedaOperatorReconcileErrors fires when the reconcile errors of a KEDA Operator controller
+	// within 5 minutes exceed the threshold
+	// default values: threshold 5, for 15m, severity warning
+	// +optional
+	OperatorReconcileErrors AlertSpec `json:"operatorReconcileErrors,omitempty"`
+
+	// KedaCertificateExpiry fires when the certificate securing the gRPC connection between KEDA Metrics Server
+	// and KEDA Operator expires within the threshold in days, it needs the PodMonitor of the KEDA OLM operator
+	// default values: threshold 14, for 1h, severity warning
+	// +optional
+	CertificateExpiry AlertSpec `json:"certificateExpiry,omitempty"`
+}
+
+// DashboardsSpec configures the ConfigMap holding the Grafana dashboard of KEDA
+type DashboardsSpec struct {
+
+	// Whether the dashboard ConfigMap is installed
+	// default value: false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespace the dashboard ConfigMap is installed to, e.g. the namespace watched by a Grafana sidecar
+	// default value: openshift-config-managed on OpenShift, the namespace of the KedaController otherwise
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Labels added to the dashboard ConfigMap, e.g. 'grafana_dashboard: "1"' to be picked up by a Grafana sidecar
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+}
+
+// AlertSpec overrides the defaults of a single alert
+type AlertSpec struct {
+
+	// Whether the alert is part of the PrometheusRule
+	// default value: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Threshold of the alert, its unit depends on the alert
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+
+	// How long the condition has to hold before the alert fires, e.g. '15m'
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	For string `json:"for,omitempty"`
+
+	// Value of the severity label of the alert, e.g. 'warning' or 'critical'
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// URL of the runbook of the alert, added as the runbook_url annotation
+	// +optional
+	RunbookURL string `json:"runbookURL,omitempty"`
+}
+
+// IsEnabled returns whether the alert is part of the PrometheusRule
+func (s AlertSpec) IsEnabled() bool {
+	return isEnabled(s.Enabled)
+}
+
+// RelabelConfig is a Prometheus relabeling step, it has the same fields as the RelabelConfig of the Prometheus Operator
+type RelabelConfig struct {
+
+	// Labels whose values are concatenated with separator and matched against regex
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Separator placed between the concatenated source label values
+	// default value: ;
+	// +optional
+	Separator *string `json:"separator,omitempty"`
+
+	// Label the result is written to for the replace, hashmod, lowercase and uppercase actions
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// Regular expression the concatenated source label values are matched against
+	// default value: (.*)
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// Modulus of the hash of the source label values for the hashmod action
+	// +optional
+	Modulus uint64 `json:"modulus,omitempty"`
+
+	// Replacement value for the replace action, regex capture groups are available
+	// default value: $1
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+
+	// Action performed based on the regex match
+	// default value: replace
+	// +kubebuilder:validation:Enum=replace;keep;drop;hashmod;labelmap;labeldrop;labelkeep;lowercase;uppercase;keepequal;dropequal
+	// +optional
+	Action string `json:"action,omitempty"`
 }
 
 // KedaControllerStatus defines the observed state of KedaController
 type KedaControllerStatus struct {
+	// Phase is a short summary of the installation state, kept for compatibility.
+	// Conditions carry the detailed, per component state.
 	// +optional
 	Phase KedaControllerPhase `json:"phase,omitempty"`
 	// +optional
 	Reason string `json:"reason,omitempty"`
 	// +optional
 	Version string `json:"version,omitempty"`
+	// Checksum of the ConfigMaps with the CA bundle mounted by KEDA Metrics Server
 	// +optional
 	ConfigMapDataSum string `json:"configmapdatasum,omitempty"`
+	// Checksum of the Secrets with the certificates mounted by KEDA Metrics Server
 	// +optional
 	SecretDataSum string `json:"secretdatasum,omitempty"`
 
+	// How the KEDA components are monitored, either 'PrometheusOperator', 'Disabled' or 'Unavailable'
+	// when the Prometheus Operator CRDs are not present in the cluster
+	// +optional
+	MonitoringMode MonitoringMode `json:"monitoringMode,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the KedaController
+	// spec that was reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the KEDA installation as a whole
+	// (Available, Progressing, Degraded) and of each of its components
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
@@ -254,16 +1098,66 @@ func (kcs *KedaControllerStatus) SetReason(r string) {
 func (kcs *KedaControllerStatus) MarkIgnored(r string) {
 	kcs.Phase = PhaseIgnored
 	kcs.Reason = r
+	kcs.setCondition(ConditionAvailable, metav1.ConditionFalse, ReasonIgnored, r)
+	kcs.setCondition(ConditionProgressing, metav1.ConditionFalse, ReasonIgnored, r)
+	kcs.setCondition(ConditionDegraded, metav1.ConditionFalse, ReasonIgnored, r)
 }
 
 func (kcs *KedaControllerStatus) MarkInstallSucceeded(r string) {
 	kcs.Phase = PhaseInstallSucceeded
 	kcs.Reason = r
+	kcs.setCondition(ConditionAvailable, metav1.ConditionTrue, ReasonInstallSucceeded, r)
+	kcs.setCondition(ConditionProgressing, metav1.ConditionFalse, ReasonInstallSucceeded, r)
+	kcs.setCondition(ConditionDegraded, metav1.ConditionFalse, ReasonInstallSucceeded, r)
+}
+
+func (kcs *KedaControllerStatus) MarkInstallInProgress(r string) {
+	kcs.Phase = PhaseInProgress
+	kcs.Reason = r
+	kcs.setCondition(ConditionAvailable, metav1.ConditionFalse, ReasonRolloutInProgress, r)
+	kcs.setCondition(ConditionProgressing, metav1.ConditionTrue, ReasonRolloutInProgress, r)
+	kcs.setCondition(ConditionDegraded, metav1.ConditionFalse, ReasonRolloutInProgress, r)
 }
 
 func (kcs *KedaControllerStatus) MarkInstallFailed(r string) {
 	kcs.Phase = PhaseFailed
 	kcs.Reason = r
+	kcs.setCondition(ConditionAvailable, metav1.ConditionFalse, ReasonInstallFailed, r)
+	kcs.setCondition(ConditionProgressing, metav1.ConditionFalse, ReasonInstallFailed, r)
+	kcs.setCondition(ConditionDegraded, metav1.ConditionTrue, ReasonInstallFailed, r)
+}
+
+// MarkComponentReady sets the condition of a single KEDA component to True
+func (kcs *KedaControllerStatus) MarkComponentReady(conditionType, reason, message string) {
+	kcs.setCondition(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+// MarkComponentFailed sets the condition of a single KEDA component to False,
+// message should carry the underlying error
+func (kcs *KedaControllerStatus) MarkComponentFailed(conditionType, reason, message string) {
+	kcs.setCondition(conditionType, metav1.ConditionFalse, reason, message)
+}
+
+// MarkComponentDisabled sets the condition of a KEDA component which is disabled in the spec to False
+func (kcs *KedaControllerStatus) MarkComponentDisabled(conditionType, message string) {
+	kcs.setCondition(conditionType, metav1.ConditionFalse, ReasonDisabled, message)
+}
+
+// GetCondition returns the condition with the given type, or nil if it is not set
+func (kcs *KedaControllerStatus) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(kcs.Conditions, conditionType)
+}
+
+// setCondition records the condition against the ObservedGeneration, so callers
+// have to set ObservedGeneration before marking anything
+func (kcs *KedaControllerStatus) setCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&kcs.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: kcs.ObservedGeneration,
+		Reason:             reason,
+		Message:            message,
+	})
 }
 
 // AuditConfig defines basic audit logging arguments user can define. If more
@@ -42,6 +42,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda-olm-operator/internal/controller/keda"
+	webhookkedav1alpha1 "github.com/kedacore/keda-olm-operator/internal/webhook/keda/v1alpha1"
 	"github.com/kedacore/keda-olm-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -107,21 +108,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "KedaController")
 		os.Exit(1)
 	}
-	if err = (&kedacontrollers.ConfigMapReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ConfigMap"),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr, installNamespace); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ConfigMap")
-		os.Exit(1)
-	}
-	if err = (&kedacontrollers.SecretReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Secret"),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr, installNamespace); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Secret")
-		os.Exit(1)
+	// the webhook server needs serving certificates, which are provided by OLM, so it can be disabled when running locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookkedav1alpha1.SetupKedaControllerWebhookWithManager(mgr, installNamespace); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KedaController")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
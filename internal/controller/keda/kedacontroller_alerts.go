@@ -0,0 +1,291 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	prometheusRuleName = "keda-alerts"
+)
+
+// certificateExpiration is scraped through the PodMonitor of the operator, KEDA itself doesn't expose
+// the expiration of the certificates it generates
+var certificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "keda_olm_operator_certificate_expiration_timestamp_seconds",
+	Help: "Expiration time of the certificate stored in a Secret used by the KEDA components, in seconds since the Unix epoch",
+}, []string{"secret"})
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiration)
+}
+
+// alertRule is an alert of the PrometheusRule together with its defaults
+type alertRule struct {
+	name string
+	spec func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec
+	// expr renders the expression of the alert for the installation namespace and the threshold
+	expr        func(namespace string, threshold int32) string
+	threshold   int32
+	forDuration string
+	severity    string
+	summary     string
+	description string
+	// enabled reports whether the alerted component is installed, nil for alerts which always apply
+	enabled func(spec *kedav1alpha1.KedaControllerSpec) bool
+}
+
+var alertRules = []alertRule{
+	{
+		name: "KedaMetricsAPIServiceUnavailable",
+		spec: func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec {
+			return alerts.MetricsAPIServiceUnavailable
+		},
+		expr: func(string, int32) string {
+			return fmt.Sprintf(`max(aggregator_unavailable_apiservice{name="%s"}) == 1`, externalMetricsAPIServiceName)
+		},
+		forDuration: "5m",
+		severity:    "critical",
+		summary:     "KEDA Metrics Server is unavailable",
+		description: "The APIService " + externalMetricsAPIServiceName + " is unavailable, the HPAs of ScaledObjects can't read their metrics.",
+		enabled:     func(spec *kedav1alpha1.KedaControllerSpec) bool { return spec.MetricsServer.IsEnabled() },
+	},
+	{
+		name: "KedaScalerErrors",
+		spec: func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec { return alerts.ScalerErrors },
+		expr: func(namespace string, threshold int32) string {
+			return fmt.Sprintf(`sum by (exported_namespace, scaledObject, scaler) (increase({__name__=~"keda_scaler_errors(_total)?", namespace="%s"}[5m])) > %d`,
+				namespace, threshold)
+		},
+		threshold:   10,
+		forDuration: "15m",
+		severity:    "warning",
+		summary:     "KEDA scaler errors are rising",
+		description: "Scaler {{ $labels.scaler }} of ScaledObject {{ $labels.exported_namespace }}/{{ $labels.scaledObject }} failed {{ $value }} times within 5 minutes.",
+	},
+	{
+		name: "KedaScaledObjectErrors",
+		spec: func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec { return alerts.ScaledObjectErrors },
+		expr: func(namespace string, threshold int32) string {
+			return fmt.Sprintf(`sum by (exported_namespace, scaledObject) (increase({__name__=~"keda_scaled_object_errors(_total)?", namespace="%s"}[5m])) > %d`,
+				namespace, threshold)
+		},
+		threshold:   0,
+		forDuration: "15m",
+		severity:    "warning",
+		summary:     "KEDA ScaledObject is not Ready",
+		description: "ScaledObject {{ $labels.exported_namespace }}/{{ $labels.scaledObject }} keeps failing and is likely not Ready.",
+	},
+	{
+		name: "KedaOperatorReconcileErrors",
+		spec: func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec { return alerts.OperatorReconcileErrors },
+		expr: func(namespace string, threshold int32) string {
+			return fmt.Sprintf(`sum by (controller) (increase(controller_runtime_reconcile_errors_total{job="keda-operator", namespace="%s"}[5m])) > %d`,
+				namespace, threshold)
+		},
+		threshold:   5,
+		forDuration: "15m",
+		severity:    "warning",
+		summary:     "KEDA Operator fails to reconcile",
+		description: "Controller {{ $labels.controller }} of KEDA Operator failed to reconcile {{ $value }} times within 5 minutes.",
+	},
+	{
+		name: "KedaCertificateExpiry",
+		spec: func(alerts *kedav1alpha1.AlertsSpec) kedav1alpha1.AlertSpec { return alerts.CertificateExpiry },
+		expr: func(namespace string, threshold int32) string {
+			return fmt.Sprintf(`keda_olm_operator_certificate_expiration_timestamp_seconds{namespace="%s"} - time() < %d * 86400`,
+				namespace, threshold)
+		},
+		threshold:   14,
+		forDuration: "1h",
+		severity:    "warning",
+		summary:     "KEDA certificate expires soon",
+		description: "The certificate in Secret {{ $labels.secret }} securing the gRPC connection between KEDA Metrics Server and KEDA Operator expires in {{ $value | humanizeDuration }}.",
+	},
+}
+
+// prometheusRule renders the PrometheusRule with the alerts enabled in spec.monitoring.alerts,
+// it is nil when every alert is disabled
+func prometheusRule(instance *kedav1alpha1.KedaController) *unstructured.Unstructured {
+	alerts := instance.Spec.Monitoring.Alerts
+
+	rules := []interface{}{}
+	for _, rule := range alertRules {
+		spec := rule.spec(&alerts)
+		if !spec.IsEnabled() || (rule.enabled != nil && !rule.enabled(&instance.Spec)) {
+			continue
+		}
+
+		threshold, forDuration, severity := rule.threshold, rule.forDuration, rule.severity
+		if spec.Threshold != nil {
+			threshold = *spec.Threshold
+		}
+		if spec.For != "" {
+			forDuration = spec.For
+		}
+		if spec.Severity != "" {
+			severity = spec.Severity
+		}
+
+		labels := map[string]interface{}{}
+		for key, value := range alerts.AdditionalLabels {
+			labels[key] = value
+		}
+		labels["severity"] = severity
+
+		annotations := map[string]interface{}{
+			"summary":     rule.summary,
+			"description": rule.description,
+		}
+		if spec.RunbookURL != "" {
+			annotations["runbook_url"] = spec.RunbookURL
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"alert":       rule.name,
+			"expr":        rule.expr(instance.Namespace, threshold),
+			"for":         forDuration,
+			"labels":      labels,
+			"annotations": annotations,
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata": map[string]interface{}{
+			"name":      prometheusRuleName,
+			"namespace": instance.Namespace,
+		},
+		"spec": map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"name":  "keda",
+					"rules": rules,
+				},
+			},
+		},
+	}}
+
+	labels := map[string]string{"app.kubernetes.io/part-of": "keda"}
+	for key, value := range instance.Spec.Monitoring.AdditionalLabels {
+		labels[key] = value
+	}
+	u.SetLabels(labels)
+	return u
+}
+
+// installPrometheusRule installs the PrometheusRule with the alerts when they are enabled and removes it otherwise
+func (r *KedaControllerReconciler) installPrometheusRule(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	if !util.HasPrometheusRuleCRD(ctx, logger, r.Client) {
+		if instance.Spec.Monitoring.Alerts.Enabled {
+			logger.Info("PrometheusRule CRD not found, skipping alerts")
+		}
+		return nil
+	}
+
+	rule := prometheusRule(instance)
+	// a PrometheusRule without any alert is removed as well, its group would have no rules
+	if !instance.Spec.Monitoring.IsEnabled() || !instance.Spec.Monitoring.Alerts.Enabled || rule == nil {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"})
+		existing.SetName(prometheusRuleName)
+		existing.SetNamespace(instance.Namespace)
+		if err := r.Client.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Unable to remove PrometheusRule")
+			return err
+		}
+		return nil
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*rule}))
+	if err != nil {
+		return err
+	}
+	manifest, err = manifest.Transform(transform.InjectOwner(instance))
+	if err != nil {
+		return err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install PrometheusRule")
+		return err
+	}
+	return nil
+}
+
+// recordCertificateExpiration exposes the expiration of the certificate securing the gRPC connection
+// between KEDA Metrics Server and KEDA Operator, it is removed while the Secret holds no certificate and when the
+// certificates provider changed to one with another Secret
+func (r *KedaControllerReconciler) recordCertificateExpiration(ctx context.Context, logger logr.Logger, namespace, secretName string) {
+	// the Secret provided by the user may have any name, so the expiration of every other one is removed
+	certificateExpiration.Reset()
+	if secretName == "" {
+		return
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Unable to get certificates Secret", "Secret.Name", secretName)
+		}
+		return
+	}
+
+	expiration, err := util.CertificateExpiration(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		logger.V(1).Info("Certificates Secret holds no certificate yet", "Secret.Name", secretName, "error", err)
+		return
+	}
+	certificateExpiration.WithLabelValues(secretName).Set(float64(expiration.Unix()))
+}
+
+// kedaControllerForSecret returns a handler.MapFunc enqueuing the KedaController when the certificates Secret
+// or a Secret referenced by env, envFrom or the trusted CAs of any KEDA component changes
+func (r *KedaControllerReconciler) kedaControllerForSecret() handler.MapFunc {
+	forReference := r.kedaControllerForReference(true)
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		certificatesSecrets := []string{grpcClientCertsSecretName, certManagerCertificateName, metricsServerServiceCASecretName}
+		if obj.GetNamespace() == r.resourceNamespace && slices.Contains(certificatesSecrets, obj.GetName()) {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}}}
+		}
+		return forReference(ctx, obj)
+	}
+}
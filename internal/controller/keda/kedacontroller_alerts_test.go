@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// renderedAlerts returns the alerts of the PrometheusRule rendered for the instance by their name
+func renderedAlerts(instance *kedav1alpha1.KedaController) map[string]map[string]interface{} {
+	rule := prometheusRule(instance)
+	if rule == nil {
+		return nil
+	}
+	groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(found).To(BeTrue())
+	Expect(groups).To(HaveLen(1))
+
+	alerts := map[string]map[string]interface{}{}
+	for _, r := range groups[0].(map[string]interface{})["rules"].([]interface{}) {
+		alert := r.(map[string]interface{})
+		alerts[alert["alert"].(string)] = alert
+	}
+	return alerts
+}
+
+var _ = Describe("Rendering the PrometheusRule of the KEDA alerts", func() {
+	disabled := false
+	threshold := int32(50)
+
+	ruleData := []struct {
+		context string
+		modify  func(*kedav1alpha1.KedaController)
+		check   func(map[string]map[string]interface{})
+	}{
+		{
+			context: "When no alert is overridden",
+			modify:  func(*kedav1alpha1.KedaController) {},
+			check: func(alerts map[string]map[string]interface{}) {
+				Expect(alerts).To(HaveLen(len(alertRules)))
+				for _, rule := range alertRules {
+					Expect(alerts).To(HaveKey(rule.name))
+					alert := alerts[rule.name]
+					Expect(alert["expr"]).To(Equal(rule.expr("keda", rule.threshold)))
+					Expect(alert["for"]).To(Equal(rule.forDuration))
+					Expect(alert["labels"]).To(Equal(map[string]interface{}{"severity": rule.severity}))
+					Expect(alert["annotations"]).NotTo(HaveKey("runbook_url"))
+				}
+				Expect(alerts["KedaScalerErrors"]["expr"]).To(HaveSuffix("> 10"))
+			},
+		},
+		{
+			context: "When the threshold, for, severity and runbook URL of an alert are overridden",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Alerts.ScalerErrors = kedav1alpha1.AlertSpec{
+					Threshold:  &threshold,
+					For:        "30m",
+					Severity:   "critical",
+					RunbookURL: "https://runbooks.example.com/keda/scaler-errors",
+				}
+			},
+			check: func(alerts map[string]map[string]interface{}) {
+				alert := alerts["KedaScalerErrors"]
+				Expect(alert["expr"]).To(HaveSuffix("> 50"))
+				Expect(alert["for"]).To(Equal("30m"))
+				Expect(alert["labels"]).To(HaveKeyWithValue("severity", "critical"))
+				Expect(alert["annotations"]).To(HaveKeyWithValue("runbook_url", "https://runbooks.example.com/keda/scaler-errors"))
+
+				Expect(alerts["KedaOperatorReconcileErrors"]["for"]).To(Equal("15m"))
+				Expect(alerts["KedaOperatorReconcileErrors"]["labels"]).To(HaveKeyWithValue("severity", "warning"))
+			},
+		},
+		{
+			context: "When additional labels are set",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Alerts.AdditionalLabels = map[string]string{"team": "platform", "severity": "info"}
+				k.Spec.Monitoring.Alerts.CertificateExpiry.Severity = "critical"
+			},
+			check: func(alerts map[string]map[string]interface{}) {
+				for _, alert := range alerts {
+					Expect(alert["labels"]).To(HaveKeyWithValue("team", "platform"))
+				}
+				Expect(alerts["KedaCertificateExpiry"]["labels"]).To(HaveKeyWithValue("severity", "critical"))
+				Expect(alerts["KedaScaledObjectErrors"]["labels"]).To(HaveKeyWithValue("severity", "warning"))
+			},
+		},
+		{
+			context: "When a single alert is disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Alerts.ScaledObjectErrors.Enabled = &disabled
+			},
+			check: func(alerts map[string]map[string]interface{}) {
+				Expect(alerts).To(HaveLen(len(alertRules) - 1))
+				Expect(alerts).NotTo(HaveKey("KedaScaledObjectErrors"))
+			},
+		},
+		{
+			context: "When the metrics server is disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.Enabled = &disabled
+			},
+			check: func(alerts map[string]map[string]interface{}) {
+				Expect(alerts).To(HaveLen(len(alertRules) - 1))
+				Expect(alerts).NotTo(HaveKey("KedaMetricsAPIServiceUnavailable"))
+			},
+		},
+		{
+			context: "When every alert is disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Alerts.MetricsAPIServiceUnavailable.Enabled = &disabled
+				k.Spec.Monitoring.Alerts.ScalerErrors.Enabled = &disabled
+				k.Spec.Monitoring.Alerts.ScaledObjectErrors.Enabled = &disabled
+				k.Spec.Monitoring.Alerts.OperatorReconcileErrors.Enabled = &disabled
+				k.Spec.Monitoring.Alerts.CertificateExpiry.Enabled = &disabled
+			},
+			check: func(alerts map[string]map[string]interface{}) {
+				Expect(alerts).To(BeNil())
+			},
+		},
+	}
+	for _, tt := range ruleData {
+		Context(tt.context, func() {
+			It("Should render the enabled alerts", func() {
+				instance := &kedav1alpha1.KedaController{
+					ObjectMeta: metav1.ObjectMeta{Name: "keda", Namespace: "keda"},
+					Spec: kedav1alpha1.KedaControllerSpec{
+						Monitoring: kedav1alpha1.MonitoringSpec{Alerts: kedav1alpha1.AlertsSpec{Enabled: true}},
+					},
+				}
+				tt.modify(instance)
+				tt.check(renderedAlerts(instance))
+			})
+		})
+	}
+
+	It("Should label the PrometheusRule with the additional labels of the monitors", func() {
+		instance := &kedav1alpha1.KedaController{
+			ObjectMeta: metav1.ObjectMeta{Name: "keda", Namespace: "keda"},
+			Spec: kedav1alpha1.KedaControllerSpec{
+				Monitoring: kedav1alpha1.MonitoringSpec{
+					AdditionalLabels: map[string]string{"release": "prometheus"},
+					Alerts:           kedav1alpha1.AlertsSpec{Enabled: true},
+				},
+			},
+		}
+		rule := prometheusRule(instance)
+		Expect(rule.GetName()).To(Equal(prometheusRuleName))
+		Expect(rule.GetNamespace()).To(Equal("keda"))
+		Expect(rule.GetLabels()).To(Equal(map[string]string{"app.kubernetes.io/part-of": "keda", "release": "prometheus"}))
+	})
+})
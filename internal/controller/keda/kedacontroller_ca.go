@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+)
+
+const (
+	// checksum of the ConfigMaps and Secrets with the trusted CAs of a KEDA component, a change rolls out the Deployment
+	caChecksumAnnotation = "olm-operator.keda.sh/ca-checksum"
+)
+
+// trustedCATransforms returns the transformations mounting the trusted CAs of a KEDA component, the ConfigMaps in
+// configMaps followed by the CA sources of spec and, on OpenShift, the trusted CA bundle of the cluster when requested,
+// and stamping the checksum of their data into the pod template
+func (r *KedaControllerReconciler) trustedCATransforms(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController, resource string,
+	spec kedav1alpha1.TrustedCASpec, configMaps []string, extraCADirs []string, runningOnOpenshift bool) ([]mf.Transformer, error) {
+	var sources []kedav1alpha1.CASource
+	for _, name := range configMaps {
+		sources = append(sources, kedav1alpha1.CASource{ConfigMap: name})
+	}
+	sources = append(sources, spec.CASources...)
+	if spec.ClusterTrustedCABundle && runningOnOpenshift {
+		sources = append(sources, kedav1alpha1.CASource{ConfigMap: trustedCABundleConfigMapName})
+	}
+
+	var volumeSources []corev1.VolumeSource
+	var referencedConfigMaps, referencedSecrets []string
+	for _, source := range sources {
+		switch {
+		case source.ConfigMap != "" && !slices.Contains(referencedConfigMaps, source.ConfigMap):
+			referencedConfigMaps = append(referencedConfigMaps, source.ConfigMap)
+			volumeSources = append(volumeSources, corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: source.ConfigMap},
+			}})
+		case source.Secret != "" && !slices.Contains(referencedSecrets, source.Secret):
+			referencedSecrets = append(referencedSecrets, source.Secret)
+			volumeSources = append(volumeSources, corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: source.Secret}})
+		}
+	}
+
+	transforms := transform.EnsureTrustedCAs(volumeSources, extraCADirs, resource, r.Scheme, logger)
+	if len(volumeSources) == 0 {
+		return transforms, nil
+	}
+
+	checksum, err := r.referencesChecksum(ctx, instance.Namespace, referencedConfigMaps, referencedSecrets)
+	if err != nil {
+		logger.Error(err, "Unable to read the trusted CAs", "resource", resource)
+		return nil, err
+	}
+	return append(transforms, transform.AddPodAnnotations(map[string]string{caChecksumAnnotation: checksum}, r.Scheme)), nil
+}
+
+// clusterTrustedCABundleRequested returns whether any KEDA component trusts the CA bundle of the cluster
+func clusterTrustedCABundleRequested(instance *kedav1alpha1.KedaController) bool {
+	return instance.Spec.Operator.ClusterTrustedCABundle ||
+		instance.Spec.MetricsServer.ClusterTrustedCABundle ||
+		instance.Spec.AdmissionWebhooks.ClusterTrustedCABundle
+}
@@ -0,0 +1,463 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	// the Certificate of the KEDA components issued by cert-manager and its Secret, mounted by all of them
+	certManagerCertificateName = "keda-cert-manager-certs"
+	// the self-signed CA signing certManagerCertificateName unless an issuer is set in the KedaController
+	certManagerSelfSignedIssuerName = "keda-cert-manager-selfsigned"
+	certManagerCAName               = "keda-cert-manager-ca"
+	certManagerGroup                = "cert-manager.io"
+
+	// the cainjector of cert-manager injects the CA of the referenced Certificate into the annotated resources
+	certManagerInjectCAFromAnnotation = "cert-manager.io/inject-ca-from"
+
+	certificatesLabel      = "olm-operator.keda.sh/certificates"
+	certificatesLabelValue = "keda"
+
+	// checksum of the Secrets and the CA bundle provided by the user, a change rolls out the Deployments
+	certificatesChecksumAnnotation = "olm-operator.keda.sh/certificates-checksum"
+	// key of the CA bundle provided by the user and its file in the certificates volume
+	caBundleKey = "ca.crt"
+	// KEDA Metrics Server mounts its serving certificate next to the gRPC client certificate in tls.crt and tls.key
+	userProvidedServingCertFile = "serving-tls.crt"
+	userProvidedServingKeyFile  = "serving-tls.key"
+)
+
+var (
+	certificateGVK = schema.GroupVersionKind{Group: certManagerGroup, Version: "v1", Kind: "Certificate"}
+	issuerGVK      = schema.GroupVersionKind{Group: certManagerGroup, Version: "v1", Kind: "Issuer"}
+
+	// the services of the KEDA components served with certManagerCertificateName
+	certificatesServiceNames = []string{"keda-operator", "keda-metrics-apiserver", "keda-admission-webhooks"}
+)
+
+// certificatesProvider returns the provider of the serving certificates set in the KedaController, by default
+// the OpenShift service CA operator on OpenShift and KEDA Operator elsewhere
+func (r *KedaControllerReconciler) certificatesProvider(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) kedav1alpha1.CertificatesProvider {
+	if provider := instance.Spec.Certificates.Provider; provider != "" {
+		return provider
+	}
+	if util.RunningOnOpenshift(ctx, logger, r.Client) {
+		return kedav1alpha1.CertificatesProviderOpenShiftServiceCA
+	}
+	return kedav1alpha1.CertificatesProviderKedaBuiltin
+}
+
+// certificatesSecretName returns the Secret with the certificate the KEDA components authenticate each other with
+func certificatesSecretName(instance *kedav1alpha1.KedaController, provider kedav1alpha1.CertificatesProvider) string {
+	switch provider {
+	case kedav1alpha1.CertificatesProviderCertManager:
+		return certManagerCertificateName
+	case kedav1alpha1.CertificatesProviderUserProvided:
+		return instance.Spec.Certificates.UserProvided.GRPCSecret
+	}
+	return grpcClientCertsSecretName
+}
+
+// installCertificates installs the cert-manager resources issuing the certificates when it is the provider,
+// removes them otherwise and reports the state of the certificates in the status
+func (r *KedaControllerReconciler) installCertificates(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	status *kedav1alpha1.KedaControllerStatus) error {
+	provider := r.certificatesProvider(ctx, logger, instance)
+	if r.rotatorStarted && provider != kedav1alpha1.CertificatesProviderOpenShiftServiceCA {
+		// a started rotator can't be stopped, it only writes the Secret no KEDA component mounts anymore
+		logger.Info("The certificates rotation started for the OpenShift service CA operator keeps running until the operator is restarted",
+			"Secret.Name", grpcClientCertsSecretName, "provider", provider)
+	}
+	if provider != kedav1alpha1.CertificatesProviderCertManager {
+		if err := r.removeCertManagerResources(ctx, logger, nil); err != nil {
+			return err
+		}
+		switch {
+		case provider == kedav1alpha1.CertificatesProviderUserProvided:
+			// the certificates are verified before any Deployment mounting them is rolled out
+			if _, _, err := r.userProvidedCertificates(ctx, instance); err != nil {
+				logger.Error(err, "User-provided certificates are not valid")
+				return err
+			}
+			status.MarkComponentReady(kedav1alpha1.ConditionCertificatesReady, kedav1alpha1.ReasonInstallSucceeded,
+				"Certificates are provided by the user and valid for the Services of the KEDA components")
+		case provider == kedav1alpha1.CertificatesProviderKedaBuiltin:
+			status.MarkComponentReady(kedav1alpha1.ConditionCertificatesReady, kedav1alpha1.ReasonInstallSucceeded,
+				"Certificates are generated and rotated by KEDA Operator")
+		case util.RunningOnOpenshift(ctx, logger, r.Client):
+			status.MarkComponentReady(kedav1alpha1.ConditionCertificatesReady, kedav1alpha1.ReasonInstallSucceeded,
+				"Certificates are issued by the OpenShift service CA operator")
+		default:
+			status.MarkComponentFailed(kedav1alpha1.ConditionCertificatesReady, "ServiceCANotFound",
+				"The OpenShift service CA operator is only available on OpenShift")
+		}
+		return nil
+	}
+	logger.Info("Reconciling cert-manager certificates")
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(certificateGVK)
+	if err := r.Client.List(ctx, list, client.InNamespace(instance.Namespace), client.Limit(1)); err != nil {
+		if !meta.IsNoMatchError(err) {
+			logger.Error(err, "Unable to list cert-manager Certificates")
+			return err
+		}
+		status.MarkComponentFailed(kedav1alpha1.ConditionCertificatesReady, "CertManagerNotFound",
+			"cert-manager CRDs are not present in the cluster")
+		return nil
+	}
+
+	manifest, err := certManagerManifest(instance)
+	if err != nil {
+		return err
+	}
+	if manifest, err = manifest.Transform(transform.InjectOwner(instance)); err != nil {
+		return err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install cert-manager certificates")
+		return err
+	}
+	keep := map[string]bool{}
+	for _, resource := range manifest.Resources() {
+		keep[resource.GetKind()+"/"+resource.GetName()] = true
+	}
+	if err := r.removeCertManagerResources(ctx, logger, keep); err != nil {
+		return err
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: certManagerCertificateName, Namespace: instance.Namespace}, certificate); err != nil {
+		return err
+	}
+	if ready, message := certificateReady(certificate); !ready {
+		status.MarkComponentFailed(kedav1alpha1.ConditionCertificatesReady, "CertificateNotReady",
+			fmt.Sprintf("Waiting for cert-manager to issue Certificate %s: %s", certManagerCertificateName, message))
+		return nil
+	}
+	status.MarkComponentReady(kedav1alpha1.ConditionCertificatesReady, kedav1alpha1.ReasonInstallSucceeded,
+		fmt.Sprintf("Certificate %s is issued by cert-manager", certManagerCertificateName))
+	return nil
+}
+
+// certManagerManifest renders the Certificate of the KEDA components and, unless an issuer is set in the KedaController,
+// the self-signed CA and the Issuer signing it
+func certManagerManifest(instance *kedav1alpha1.KedaController) (mf.Manifest, error) {
+	labels := map[string]interface{}{
+		"app.kubernetes.io/part-of": "keda",
+		certificatesLabel:           certificatesLabelValue,
+	}
+	newResource := func(gvk schema.GroupVersionKind, name string, spec map[string]interface{}) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "namespace": instance.Namespace, "labels": labels},
+			"spec":     spec,
+		}}
+		u.SetGroupVersionKind(gvk)
+		return u
+	}
+
+	spec := instance.Spec.Certificates.CertManager
+	var resources []unstructured.Unstructured
+	issuerRef := map[string]interface{}{"name": certManagerCAName, "kind": issuerGVK.Kind, "group": certManagerGroup}
+	if ref := spec.IssuerRef; ref != nil {
+		issuerRef = map[string]interface{}{"name": ref.Name, "kind": issuerGVK.Kind, "group": certManagerGroup}
+		if ref.Kind != "" {
+			issuerRef["kind"] = ref.Kind
+		}
+		if ref.Group != "" {
+			issuerRef["group"] = ref.Group
+		}
+	} else {
+		resources = append(resources,
+			newResource(issuerGVK, certManagerSelfSignedIssuerName, map[string]interface{}{"selfSigned": map[string]interface{}{}}),
+			// the names of the CA match the ones KEDA Operator uses for its own
+			newResource(certificateGVK, certManagerCAName, map[string]interface{}{
+				"isCA":       true,
+				"commonName": "KEDA",
+				"subject":    map[string]interface{}{"organizations": []interface{}{"KEDAORG"}},
+				"secretName": certManagerCAName,
+				"privateKey": map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)},
+				"issuerRef":  map[string]interface{}{"name": certManagerSelfSignedIssuerName, "kind": issuerGVK.Kind, "group": certManagerGroup},
+			}),
+			newResource(issuerGVK, certManagerCAName, map[string]interface{}{"ca": map[string]interface{}{"secretName": certManagerCAName}}),
+		)
+	}
+
+	var dnsNames []interface{}
+	for _, service := range certificatesServiceNames {
+		dnsNames = append(dnsNames,
+			service,
+			service+"."+instance.Namespace,
+			service+"."+instance.Namespace+".svc",
+			service+"."+instance.Namespace+".svc.cluster.local",
+		)
+	}
+	certificateSpec := map[string]interface{}{
+		"secretName": certManagerCertificateName,
+		"commonName": certificatesServiceNames[0] + "." + instance.Namespace + ".svc",
+		"dnsNames":   dnsNames,
+		// the certificate serves the components and authenticates KEDA Metrics Server to KEDA Operator
+		"usages":     []interface{}{"server auth", "client auth", "digital signature", "key encipherment"},
+		"privateKey": map[string]interface{}{"rotationPolicy": "Always"},
+		"issuerRef":  issuerRef,
+	}
+	if spec.Duration != nil {
+		certificateSpec["duration"] = spec.Duration.Duration.String()
+	}
+	if spec.RenewBefore != nil {
+		certificateSpec["renewBefore"] = spec.RenewBefore.Duration.String()
+	}
+	resources = append(resources, newResource(certificateGVK, certManagerCertificateName, certificateSpec))
+
+	return mf.ManifestFrom(mf.Slice(resources))
+}
+
+// certificateReady returns whether the Ready condition of a cert-manager Certificate is True, and its message otherwise
+func certificateReady(certificate *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if condition["status"] == "True" {
+			return true, ""
+		}
+		message, _ := condition["message"].(string)
+		return false, message
+	}
+	return false, "the Certificate has no Ready condition yet"
+}
+
+// removeCertManagerResources removes the cert-manager resources created by the operator, except for the ones
+// in keep keyed by kind and name
+func (r *KedaControllerReconciler) removeCertManagerResources(ctx context.Context, logger logr.Logger, keep map[string]bool) error {
+	for _, gvk := range []schema.GroupVersionKind{certificateGVK, issuerGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := r.Client.List(ctx, list, client.InNamespace(r.resourceNamespace),
+			client.MatchingLabels{certificatesLabel: certificatesLabelValue}); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			logger.Error(err, "Unable to list cert-manager resources", "kind", gvk.Kind)
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if keep[gvk.Kind+"/"+obj.GetName()] {
+				continue
+			}
+			if err := r.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Unable to remove cert-manager resource", "kind", gvk.Kind, "name", obj.GetName())
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// userProvidedReferences returns the ConfigMaps and Secrets holding the certificates provided by the user
+func userProvidedReferences(spec kedav1alpha1.UserProvidedCertificatesSpec) (configMaps, secrets []string) {
+	configMaps, secrets = util.CAReferences([]kedav1alpha1.CASource{spec.CABundle})
+	for _, name := range []string{spec.GRPCSecret, spec.MetricsServerSecret, spec.AdmissionWebhooksSecret} {
+		if name != "" && !slices.Contains(secrets, name) {
+			secrets = append(secrets, name)
+		}
+	}
+	return configMaps, secrets
+}
+
+// grpcServerName returns the name KEDA Metrics Server verifies the gRPC certificate of KEDA Operator against
+func grpcServerName(instance *kedav1alpha1.KedaController) string {
+	for _, address := range []string{instance.Spec.MetricsServer.GRPC.MetricsServiceAuthority, instance.Spec.MetricsServer.GRPC.MetricsServiceAddress} {
+		if address == "" {
+			continue
+		}
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			return host
+		}
+		return address
+	}
+	return "keda-operator." + instance.Namespace + ".svc.cluster.local"
+}
+
+// userProvidedCertificates verifies the certificates provided by the user against their CA bundle and the names of
+// the Services of the enabled KEDA components, and returns the CA bundle and the checksum of the referenced objects
+func (r *KedaControllerReconciler) userProvidedCertificates(ctx context.Context, instance *kedav1alpha1.KedaController) ([]byte, string, error) {
+	spec := instance.Spec.Certificates.UserProvided
+	if spec.GRPCSecret == "" || (spec.CABundle.ConfigMap == "" && spec.CABundle.Secret == "") {
+		return nil, "", fmt.Errorf("the gRPC Secret and the CA bundle are required with the %s certificates provider",
+			kedav1alpha1.CertificatesProviderUserProvided)
+	}
+	// the rotator started for the OpenShift service CA operator keeps writing its certificates to grpcClientCertsSecretName
+	if _, secrets := userProvidedReferences(spec); slices.Contains(secrets, grpcClientCertsSecretName) {
+		return nil, "", fmt.Errorf("the Secret %s holds the certificates generated by KEDA Operator, "+
+			"the certificates need to be provided in another Secret", grpcClientCertsSecretName)
+	}
+
+	var caBundle []byte
+	if spec.CABundle.ConfigMap != "" {
+		cm := &corev1.ConfigMap{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: spec.CABundle.ConfigMap, Namespace: instance.Namespace}, cm); err != nil {
+			return nil, "", fmt.Errorf("unable to get the CA bundle ConfigMap %s: %w", spec.CABundle.ConfigMap, err)
+		}
+		caBundle = []byte(cm.Data[caBundleKey])
+	} else {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: spec.CABundle.Secret, Namespace: instance.Namespace}, secret); err != nil {
+			return nil, "", fmt.Errorf("unable to get the CA bundle Secret %s: %w", spec.CABundle.Secret, err)
+		}
+		caBundle = secret.Data[caBundleKey]
+	}
+
+	type certificate struct {
+		secretName string
+		dnsName    string
+		usages     []x509.ExtKeyUsage
+	}
+	// KEDA Metrics Server authenticates to KEDA Operator with the gRPC certificate too
+	certificates := []certificate{{spec.GRPCSecret, grpcServerName(instance), []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}}}
+	if instance.Spec.MetricsServer.IsEnabled() {
+		certificates = append(certificates, certificate{spec.MetricsServerSecret, "keda-metrics-apiserver." + instance.Namespace + ".svc",
+			[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	}
+	if instance.Spec.AdmissionWebhooks.IsEnabled() {
+		certificates = append(certificates, certificate{spec.AdmissionWebhooksSecret, "keda-admission-webhooks." + instance.Namespace + ".svc",
+			[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	}
+	for _, c := range certificates {
+		if c.secretName == "" {
+			return nil, "", fmt.Errorf("no Secret is set for the certificate of %s", c.dnsName)
+		}
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: c.secretName, Namespace: instance.Namespace}, secret); err != nil {
+			return nil, "", fmt.Errorf("unable to get the certificates Secret %s: %w", c.secretName, err)
+		}
+		if err := util.VerifyCertificate(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], caBundle, c.dnsName, c.usages...); err != nil {
+			return nil, "", fmt.Errorf("certificate of Secret %s is not valid for %s: %w", c.secretName, c.dnsName, err)
+		}
+	}
+
+	configMaps, secrets := userProvidedReferences(spec)
+	checksum, err := r.referencesChecksum(ctx, instance.Namespace, configMaps, secrets)
+	if err != nil {
+		return nil, "", err
+	}
+	return caBundle, checksum, nil
+}
+
+// userProvidedCertificatesVolume returns the projected source of the volume mounted at /certs, with the pair of
+// secretName in tls.crt and tls.key and the CA bundle in ca.crt, followed by the extra projections
+func userProvidedCertificatesVolume(spec kedav1alpha1.UserProvidedCertificatesSpec, secretName string,
+	extra ...corev1.VolumeProjection) corev1.VolumeSource {
+	sources := []corev1.VolumeProjection{{Secret: &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Items: []corev1.KeyToPath{
+			{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+			{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+		},
+	}}}
+	sources = append(sources, extra...)
+
+	caItems := []corev1.KeyToPath{{Key: caBundleKey, Path: caBundleKey}}
+	if spec.CABundle.ConfigMap != "" {
+		sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: spec.CABundle.ConfigMap},
+			Items:                caItems,
+		}})
+	} else {
+		sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: spec.CABundle.Secret},
+			Items:                caItems,
+		}})
+	}
+	return corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}}
+}
+
+// userProvidedCertificatesTransforms returns the transformations mounting the certificates provided by the user
+// into a KEDA component, stamping their checksum into the pod template and injecting the CA bundle
+func (r *KedaControllerReconciler) userProvidedCertificatesTransforms(ctx context.Context, logger logr.Logger,
+	instance *kedav1alpha1.KedaController, resource string) ([]mf.Transformer, error) {
+	spec := instance.Spec.Certificates.UserProvided
+	caBundle, checksum, err := r.userProvidedCertificates(ctx, instance)
+	if err != nil {
+		logger.Error(err, "User-provided certificates are not valid", "resource", resource)
+		return nil, err
+	}
+
+	transforms := []mf.Transformer{transform.AddPodAnnotations(map[string]string{certificatesChecksumAnnotation: checksum}, r.Scheme)}
+	switch resource {
+	case "operator":
+		transforms = append(transforms,
+			transform.ReplaceCertificatesVolume(userProvidedCertificatesVolume(spec, spec.GRPCSecret), r.Scheme),
+			transform.SetOperatorCertRotation(false, r.Scheme, logger),
+		)
+	case "metricsserver":
+		serving := corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: spec.MetricsServerSecret},
+			Items: []corev1.KeyToPath{
+				{Key: corev1.TLSCertKey, Path: userProvidedServingCertFile},
+				{Key: corev1.TLSPrivateKeyKey, Path: userProvidedServingKeyFile},
+			},
+		}}
+		transforms = append(transforms,
+			transform.ReplaceCertificatesVolume(userProvidedCertificatesVolume(spec, spec.GRPCSecret, serving), r.Scheme),
+			transform.EnsureCABundleForAPIService(caBundle, r.Scheme),
+		)
+		transforms = append(transforms, transform.EnsurePathsToCertsInDeployment(
+			[]string{"/certs/" + caBundleKey, "/certs/" + userProvidedServingCertFile, "/certs/" + userProvidedServingKeyFile},
+			[]transform.Prefix{transform.ClientCAFile, transform.TLSCertFile, transform.TLSPrivateKeyFile}, r.Scheme, logger)...)
+	case "admissionwebhooks":
+		transforms = append(transforms,
+			transform.ReplaceCertificatesVolume(userProvidedCertificatesVolume(spec, spec.AdmissionWebhooksSecret), r.Scheme),
+			transform.EnsureCABundleForValidatingWebhookConfiguration(caBundle, r.Scheme),
+		)
+	}
+	return transforms, nil
+}
+
+// kedaControllerForCertificate enqueues the KedaController when the cert-manager Certificate of the KEDA components changes
+func (r *KedaControllerReconciler) kedaControllerForCertificate(_ context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != r.resourceNamespace || obj.GetName() != certManagerCertificateName {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}}}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+)
+
+// uninstallMetricsServer removes the objects of a disabled KEDA Metrics Server, including the cluster-scoped
+// ones and the v1beta1.external.metrics.k8s.io APIService. Objects which are already gone are skipped.
+func (r *KedaControllerReconciler) uninstallMetricsServer(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	logger.Info("KEDA Metrics Server is disabled, removing its resources")
+
+	if err := r.deleteManifest(logger, instance, r.resourcesMetrics); err != nil {
+		logger.Error(err, "Unable to remove KEDA Metrics Server resources")
+		return err
+	}
+
+	return r.deleteObjects(ctx, logger,
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "keda-metrics-apiserver", Namespace: instance.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: auditlogPolicyConfigMap, Namespace: instance.Namespace}},
+	)
+}
+
+// uninstallAdmissionWebhooks removes the objects of disabled KEDA Admission Webhooks, including the
+// ValidatingWebhookConfiguration. Objects which are already gone are skipped.
+func (r *KedaControllerReconciler) uninstallAdmissionWebhooks(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	logger.Info("KEDA Admission Webhooks are disabled, removing their resources")
+
+	if err := r.deleteManifest(logger, instance, r.resourcesWebhooks); err != nil {
+		logger.Error(err, "Unable to remove KEDA Admission Webhooks resources")
+		return err
+	}
+
+	return r.deleteObjects(ctx, logger,
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "keda-admission", Namespace: instance.Namespace}},
+	)
+}
+
+// deleteManifest deletes the resources of a stored manifest from the namespaces they were installed to
+func (r *KedaControllerReconciler) deleteManifest(logger logr.Logger, instance *kedav1alpha1.KedaController, resources mf.Manifest) error {
+	manifest, err := resources.Transform(
+		transform.ReplaceAllNamespaces(instance.Namespace),
+		transform.ReplaceNamespace(roleBindingName, roleBindingNamespace, r.Scheme, logger),
+	)
+	if err != nil {
+		return err
+	}
+	return manifest.Delete()
+}
+
+// deleteObjects deletes objects the operator creates outside of the manifests, missing objects are skipped
+func (r *KedaControllerReconciler) deleteObjects(ctx context.Context, logger logr.Logger, objects ...client.Object) error {
+	for _, obj := range objects {
+		if err := r.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Unable to delete resource of a disabled component", "Name", obj.GetName())
+			return err
+		}
+	}
+	return nil
+}
@@ -24,7 +24,8 @@ import (
 	"os"
 	"path"
 	"reflect"
-	"strconv"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -33,6 +34,7 @@ import (
 	"github.com/open-policy-agent/cert-controller/pkg/rotator"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -41,9 +43,12 @@ import (
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/yaml"
 
@@ -67,6 +72,13 @@ const (
 	roleBindingName               = "keda-auth-reader"
 	roleBindingNamespace          = "kube-system"
 
+	// checksums of the certificates mounted by KEDA Metrics Server, a change rolls out the Deployment
+	configMapChecksumAnnotation = "olm-operator.keda.sh/configmap-checksum"
+	secretChecksumAnnotation    = "olm-operator.keda.sh/secret-checksum"
+
+	// the Secret the OpenShift service CA operator issues the serving certificate of KEDA Metrics Server into
+	metricsServerServiceCASecretName = "keda-metrics-apiserver-certs"
+
 	auditlogPolicyConfigMap = "keda-metrics-server-audit-policy"
 	auditlogPolicyMountPath = "/var/audit-policy"
 	auditPolicyFile         = "policy.yaml"
@@ -86,6 +98,7 @@ type KedaControllerReconciler struct {
 	resourcesMetrics    mf.Manifest
 	resourcesWebhooks   mf.Manifest
 	resourcesMonitoring mf.Manifest
+	resourcesDashboards mf.Manifest
 	discoveryClient     *discovery.DiscoveryClient
 	resourceNamespace   string
 }
@@ -102,11 +115,16 @@ func (r *KedaControllerReconciler) SetupWithManager(mgr ctrl.Manager, kedaContro
 		return err
 	}
 
+	// the manifests are kept as loaded from the resources, every reconcile renders them from scratch
+	// so that settings removed from the KedaController are reverted as well
 	r.resourcesGeneral = manifestGeneral
 	r.resourcesController = manifestController
 	r.resourcesMetrics = manifestMetrics
 	r.resourcesWebhooks = manifestWebhooks
 	r.resourcesMonitoring = manifestMonitoring
+	if r.resourcesDashboards, err = resources.GetDashboardsManifest(); err != nil {
+		return err
+	}
 	if restConfig, err := ctrl.GetConfig(); err != nil {
 		logger.Info("Unable to get REST Config for cluster version discovery. Ignore this message in test environments", "err", err)
 	} else {
@@ -115,21 +133,47 @@ func (r *KedaControllerReconciler) SetupWithManager(mgr ctrl.Manager, kedaContro
 		}
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.KedaController{}).
 		Owns(&appsv1.Deployment{}).
-		Complete(r)
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForConfigMap())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForSecret())).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForNamespace()),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+
+	// the config APIs are only served by OpenShift, a watch of a missing API would fail to start
+	if util.HasOpenshiftConfigAPI(logger, r.discoveryClient, proxyGVK.Kind) {
+		proxy := &unstructured.Unstructured{}
+		proxy.SetGroupVersionKind(proxyGVK)
+		controllerBuilder = controllerBuilder.Watches(proxy, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForProxy))
+	}
+	if util.HasCertManagerAPI(logger, r.discoveryClient, certificateGVK.Kind) {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		controllerBuilder = controllerBuilder.Watches(certificate, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForCertificate))
+	}
+	if util.HasOpenshiftConfigAPI(logger, r.discoveryClient, apiServerGVK.Kind) {
+		apiServer := &unstructured.Unstructured{}
+		apiServer.SetGroupVersionKind(apiServerGVK)
+		controllerBuilder = controllerBuilder.Watches(apiServer, handler.EnqueueRequestsFromMapFunc(r.kedaControllerForAPIServer))
+	}
+	return controllerBuilder.Complete(r)
 }
 
 // +kubebuilder:rbac:groups=keda.sh,resources=kedacontrollers;kedacontrollers/finalizers;kedacontrollers/status,verbs="*"
+// +kubebuilder:rbac:groups=keda.sh,resources=clustertriggerauthentications,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=namespaces;serviceaccounts;pods;services;services/finalizers;endpoints;persistentvolumeclaims;events;configmaps;secrets,verbs="*"
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs="*"
 // +kubebuilder:rbac:groups=apps,resourceNames=keda-olm-operator,resources=deployments/finalizers,verbs="*"
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;clusterroles;rolebindings;roles,verbs="*"
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=apiregistration.k8s.io,resources=apiservices,verbs=create;delete;get;list;patch;update;watch
-// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=create;delete;get;list;patch;update;watch
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=list
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs="*"
+// +kubebuilder:rbac:groups=config.openshift.io,resources=apiservers;proxies,verbs=get;list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs="*"
 
 // Reconcile reads that state of the cluster for a KedaController object and makes changes based on the state read
@@ -160,6 +204,7 @@ func (r *KedaControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		msg := fmt.Sprintf("The KedaController resource needs to be created in namespace %s with name %s, otherwise it will be ignored", r.resourceNamespace, kedaControllerResourceName)
 		logger.Info(msg)
 		status := instance.Status.DeepCopy()
+		status.ObservedGeneration = instance.Generation
 		status.MarkIgnored(msg)
 		return ctrl.Result{}, util.UpdateKedaControllerStatus(ctx, r.Client, instance, status)
 	}
@@ -169,7 +214,7 @@ func (r *KedaControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// Run finalization logic for kedaControllerFinalizer. If the
 			// finalization logic fails, don't remove the finalizer so
 			// that we can retry during the next reconciliation.
-			if err := r.finalizeKedaController(logger); err != nil {
+			if err := r.finalizeKedaController(ctx, logger, instance); err != nil {
 				return ctrl.Result{}, err
 			}
 			// Remove kedaControllerFinalizer. Once all finalizers have been
@@ -193,52 +238,107 @@ func (r *KedaControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	status := instance.Status.DeepCopy()
+	status.ObservedGeneration = instance.Generation
 
-	if err := r.installSA(logger, instance); err != nil {
-		status.MarkInstallFailed("Not able to create ServiceAccount")
-		if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
-			err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
-		}
-		return ctrl.Result{}, err
+	if err := r.installSA(ctx, logger, instance); err != nil {
+		return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionOperatorReady,
+			"Not able to create ServiceAccount", err)
+	}
+	if err := r.installCertificates(ctx, logger, instance, status); err != nil {
+		return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionCertificatesReady,
+			"Not able to install the certificates", err)
 	}
 	if err := r.installController(ctx, logger, instance); err != nil {
-		status.MarkInstallFailed("Not able to install KEDA Controller")
-		if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
-			err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
-		}
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionOperatorReady,
+			"Not able to install KEDA Controller", err)
 	}
-	if err := r.installMetricsServer(ctx, logger, instance); err != nil {
-		status.MarkInstallFailed("Not able to install KEDA Metrics Server")
-		if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
-			err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
+	if instance.Spec.MetricsServer.IsEnabled() {
+		if err := r.installMetricsServer(ctx, logger, instance, status); err != nil {
+			return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionMetricsServerReady,
+				"Not able to install KEDA Metrics Server", err)
 		}
-		return ctrl.Result{}, err
+	} else {
+		if err := r.uninstallMetricsServer(ctx, logger, instance); err != nil {
+			return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionMetricsServerReady,
+				"Not able to remove disabled KEDA Metrics Server", err)
+		}
+		status.MarkComponentDisabled(kedav1alpha1.ConditionMetricsServerReady, "KEDA Metrics Server is disabled")
 	}
 
-	if err := r.installAdmissionWebhooks(ctx, logger, instance); err != nil {
-		status.MarkInstallFailed("Not able to install KEDA Admission Webhooks")
-		if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
-			err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
+	if instance.Spec.AdmissionWebhooks.IsEnabled() {
+		if err := r.installAdmissionWebhooks(ctx, logger, instance); err != nil {
+			return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionAdmissionWebhooksReady,
+				"Not able to install KEDA Admission Webhooks", err)
 		}
-		return ctrl.Result{}, err
+	} else {
+		if err := r.uninstallAdmissionWebhooks(ctx, logger, instance); err != nil {
+			return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionAdmissionWebhooksReady,
+				"Not able to remove disabled KEDA Admission Webhooks", err)
+		}
+		status.MarkComponentDisabled(kedav1alpha1.ConditionAdmissionWebhooksReady, "KEDA Admission Webhooks are disabled")
 	}
 
-	if err := r.installMonitoring(ctx, logger, instance); err != nil {
-		status.MarkInstallFailed("Not able to install monitoring resources")
-		if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
-			err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
-		}
-		return ctrl.Result{}, err
+	monitoringMode, err := r.installMonitoring(ctx, logger, instance)
+	if err != nil {
+		return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionMonitoringReady,
+			"Not able to install monitoring resources", err)
+	}
+	status.MonitoringMode = monitoringMode
+	switch monitoringMode {
+	case kedav1alpha1.MonitoringModePrometheusOperator:
+		status.MarkComponentReady(kedav1alpha1.ConditionMonitoringReady, kedav1alpha1.ReasonInstallSucceeded, "Monitoring resources are installed")
+	case kedav1alpha1.MonitoringModeDisabled:
+		status.MarkComponentDisabled(kedav1alpha1.ConditionMonitoringReady, "Monitoring is disabled")
+	default:
+		status.MarkComponentFailed(kedav1alpha1.ConditionMonitoringReady, "MonitoringCRDsNotFound",
+			"ServiceMonitor and PodMonitor CRDs are not present in the cluster, monitoring resources are not installed")
 	}
 
+	if err := r.installOpenshiftMonitoring(ctx, logger, instance, status); err != nil {
+		return ctrl.Result{}, r.markInstallFailed(ctx, instance, status, kedav1alpha1.ConditionOpenShiftMonitoringReady,
+			"Not able to install OpenShift monitoring authentication", err)
+	}
+
+	r.recordCertificateExpiration(ctx, logger, instance.Namespace, certificatesSecretName(instance, r.certificatesProvider(ctx, logger, instance)))
+
 	status.Version = version.Version
-	status.MarkInstallSucceeded(fmt.Sprintf("KEDA v%s is installed in namespace '%s'", version.Version, r.resourceNamespace))
+
+	// all manifests are applied, now wait for the workloads to actually become available
+	rolloutState, rolloutMsg, err := r.checkWorkloadHealth(ctx, logger, instance, status)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result := ctrl.Result{}
+	switch rolloutState {
+	case util.RolloutComplete:
+		status.MarkInstallSucceeded(fmt.Sprintf("KEDA v%s is installed in namespace '%s'", version.Version, r.resourceNamespace))
+	case util.RolloutFailed:
+		status.MarkInstallFailed(fmt.Sprintf("KEDA v%s failed to roll out: %s", version.Version, rolloutMsg))
+		result.RequeueAfter = rolloutRequeueInterval
+	default:
+		status.MarkInstallInProgress(fmt.Sprintf("KEDA v%s is rolling out: %s", version.Version, rolloutMsg))
+		result.RequeueAfter = rolloutRequeueInterval
+	}
+
 	if err := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
+}
+
+// markInstallFailed records a failed installation step in the status: the
+// component condition carries the underlying error, while Phase and Reason keep
+// the short summary. It returns the error the reconcile should be requeued with.
+func (r *KedaControllerReconciler) markInstallFailed(ctx context.Context, instance *kedav1alpha1.KedaController, status *kedav1alpha1.KedaControllerStatus,
+	conditionType string, summary string, err error) error {
+	status.MarkComponentFailed(conditionType, kedav1alpha1.ReasonInstallFailed, fmt.Sprintf("%s: %s", summary, err))
+	status.MarkInstallFailed(summary)
+	if statusErr := util.UpdateKedaControllerStatus(ctx, r.Client, instance, status); statusErr != nil {
+		err = fmt.Errorf("got error: %s and then another: %s", err, statusErr)
+	}
+	return err
 }
 
 func parseManifestsFromFile(manifest mf.Manifest, c client.Client) (manifestGeneral, manifestController,
@@ -273,31 +373,31 @@ func parseManifestsFromFile(manifest mf.Manifest, c client.Client) (manifestGene
 	}
 
 	manifestClient := mfc.NewClient(c)
-	manifestGeneral, err = mf.ManifestFrom(mf.Slice(generalResources), mf.UseLastAppliedConfigAnnotation(resources.LastConfigID))
+	manifestGeneral, err = mf.ManifestFrom(mf.Slice(generalResources))
 	if err != nil {
 		return mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, err
 	}
 	manifestGeneral.Client = manifestClient
 
-	manifestController, err = mf.ManifestFrom(mf.Slice(controllerResources), mf.UseLastAppliedConfigAnnotation(resources.LastConfigID))
+	manifestController, err = mf.ManifestFrom(mf.Slice(controllerResources))
 	if err != nil {
 		return mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, err
 	}
 	manifestController.Client = manifestClient
 
-	manifestMetrics, err = mf.ManifestFrom(mf.Slice(sortMetricsResources(&metricsResources)), mf.UseLastAppliedConfigAnnotation(resources.LastConfigID))
+	manifestMetrics, err = mf.ManifestFrom(mf.Slice(sortMetricsResources(&metricsResources)))
 	if err != nil {
 		return mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, err
 	}
 	manifestMetrics.Client = manifestClient
 
-	manifestWebhook, err = mf.ManifestFrom(mf.Slice(webhookResources), mf.UseLastAppliedConfigAnnotation(resources.LastConfigID))
+	manifestWebhook, err = mf.ManifestFrom(mf.Slice(webhookResources))
 	if err != nil {
 		return mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, err
 	}
 	manifestWebhook.Client = manifestClient
 
-	manifestMonitoring, err = mf.ManifestFrom(mf.Slice(monitoringResources), mf.UseLastAppliedConfigAnnotation(resources.LastConfigID))
+	manifestMonitoring, err = mf.ManifestFrom(mf.Slice(monitoringResources))
 	if err != nil {
 		return mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, mf.Manifest{}, err
 	}
@@ -333,13 +433,22 @@ func sortMetricsResources(resources *[]unstructured.Unstructured) []unstructured
 	return sortedResources
 }
 
-func (r *KedaControllerReconciler) installSA(logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+func (r *KedaControllerReconciler) installSA(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
 	logger.Info("Reconciling KEDA ServiceAccount")
 	transforms := []mf.Transformer{
 		transform.InjectOwner(instance),
 		transform.ReplaceAllNamespaces(instance.Namespace),
 	}
 
+	identity, err := util.PodIdentityFor(instance.Spec.PodIdentity)
+	if err != nil {
+		return err
+	}
+	// the pod identity goes first, so that annotations of the KedaController override it
+	if len(identity.ServiceAccountAnnotations) > 0 {
+		transforms = append(transforms, transform.AddServiceAccountAnnotations(identity.ServiceAccountAnnotations, r.Scheme))
+	}
+
 	if len(instance.Spec.ServiceAccount.Annotations) > 0 {
 		transforms = append(transforms, transform.AddServiceAccountAnnotations(instance.Spec.ServiceAccount.Annotations, r.Scheme))
 	}
@@ -353,9 +462,8 @@ func (r *KedaControllerReconciler) installSA(logger logr.Logger, instance *kedav
 		logger.Error(err, "Unable to transform ServiceAccount manifest")
 		return err
 	}
-	r.resourcesGeneral = manifest
 
-	if err := r.resourcesGeneral.Apply(); err != nil {
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
 		logger.Error(err, "Unable to install ServiceAccount")
 		return err
 	}
@@ -365,16 +473,30 @@ func (r *KedaControllerReconciler) installSA(logger logr.Logger, instance *kedav
 
 func (r *KedaControllerReconciler) installController(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
 	logger.Info("Reconciling KEDA Controller deployment")
+	watchedNamespaces, err := r.watchedNamespaces(ctx, logger, instance)
+	if err != nil {
+		return err
+	}
 	transforms := []mf.Transformer{
 		transform.InjectOwner(instance),
 		transform.ReplaceAllNamespaces(instance.Namespace),
-		transform.ReplaceWatchNamespace(instance.Spec.WatchNamespace, "keda-operator", r.Scheme, logger),
+		transform.ReplaceWatchNamespace(strings.Join(watchedNamespaces, ","), "keda-operator", r.Scheme, logger),
 	}
 
+	rbacTransforms, err := r.watchedNamespacesRBACTransforms(ctx, logger, instance, watchedNamespaces)
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, rbacTransforms...)
+
 	runningOnOpenshift := util.RunningOnOpenshift(ctx, logger, r.Client)
 
 	caConfigMaps := instance.Spec.Operator.CAConfigMaps
 	if runningOnOpenshift {
+		if err := r.ensureOpenshiftCABundleConfigMap(ctx, logger, instance); err != nil {
+			logger.Error(err, "Unable to check OpenShift CA Bundle ConfigMap is present")
+			return err
+		}
 		found := false
 		for _, cmName := range caConfigMaps {
 			if cmName == caBundleConfigMapName {
@@ -388,31 +510,59 @@ func (r *KedaControllerReconciler) installController(ctx context.Context, logger
 		}
 	}
 
-	transforms = append(transforms, transform.EnsureCACertsForOperatorDeployment(caConfigMaps, r.Scheme, logger)...)
+	proxyEnv, err := r.proxyEnv(ctx, logger, instance)
+	if err != nil {
+		return err
+	}
+	trustedCA, err := r.trustedCABundle(ctx, logger, instance, runningOnOpenshift, proxyEnv)
+	if err != nil {
+		return err
+	}
+	if trustedCA != "" && !slices.Contains(caConfigMaps, trustedCA) {
+		caConfigMaps = append(slices.Clone(caConfigMaps), trustedCA)
+	}
+	caTransforms, err := r.trustedCATransforms(ctx, logger, instance, "operator", instance.Spec.Operator.TrustedCASpec,
+		caConfigMaps, instance.Spec.Operator.CADirs, runningOnOpenshift)
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, caTransforms...)
 
-	if runningOnOpenshift {
-		// certificates rotation works only on Openshift due to openshift/service-ca-operator
+	provider := r.certificatesProvider(ctx, logger, instance)
+	switch provider {
+	case kedav1alpha1.CertificatesProviderOpenShiftServiceCA:
+		// the service CA operator issues the serving certificate, KEDA Operator still trusts the clients of its own CA
 		serviceName := "keda-operator"
 		certsSecretName := serviceName + "-certs"
 		transforms = append(transforms,
 			transform.EnsureCertInjectionForService(serviceName, servingCertsAnnotation, certsSecretName),
 			transform.KedaOperatorEnsureCertificatesVolume(certsSecretName, grpcClientCertsSecretName, r.Scheme),
-			transform.SetOperatorCertRotation(false, r.Scheme, logger), // don't use KEDA operator's built-in cert rotation when on OpenShift
+			transform.SetOperatorCertRotation(false, r.Scheme, logger),
 		)
-		// on OpenShift 4.10 (kube 1.23) and earlier, the RuntimeDefault SeccompProfile won't validate against any SCC
-		if util.RunningOnClusterWithoutSeccompProfileDefault(logger, r.discoveryClient) {
-			transforms = append(transforms, transform.RemoveSeccompProfileFromKedaOperator(r.Scheme, logger))
-		}
-	} else {
+	case kedav1alpha1.CertificatesProviderCertManager:
 		transforms = append(transforms,
-			transform.SetOperatorCertRotation(true, r.Scheme, logger), // use KEDA operator's built-in cert rotation when not on OpenShift
+			transform.ReplaceCertificatesSecret(certManagerCertificateName, r.Scheme),
+			transform.SetOperatorCertRotation(false, r.Scheme, logger),
 		)
+	case kedav1alpha1.CertificatesProviderUserProvided:
+		certificatesTransforms, err := r.userProvidedCertificatesTransforms(ctx, logger, instance, "operator")
+		if err != nil {
+			return err
+		}
+		transforms = append(transforms, certificatesTransforms...)
+	default:
+		transforms = append(transforms, transform.SetOperatorCertRotation(true, r.Scheme, logger))
 	}
-
-	// Use alternate image spec if env var set
-	if controllerImage := os.Getenv("KEDA_OPERATOR_IMAGE"); len(controllerImage) > 0 {
-		transforms = append(transforms, transform.ReplaceKedaOperatorImage(controllerImage, r.Scheme))
+	// on OpenShift 4.10 (kube 1.23) and earlier, the RuntimeDefault SeccompProfile won't validate against any SCC
+	if runningOnOpenshift && util.RunningOnClusterWithoutSeccompProfileDefault(logger, r.discoveryClient) {
+		transforms = append(transforms, transform.RemoveSeccompProfileFromKedaOperator(r.Scheme, logger))
 	}
+	if instance.Spec.Operator.CertRotation != nil {
+		transforms = append(transforms, transform.SetOperatorCertRotation(*instance.Spec.Operator.CertRotation, r.Scheme, logger))
+	}
+
+	transforms = append(transforms, imageTransformations(instance.Spec.ImageRegistryMirror, instance.Spec.Operator.GenericDeploymentSpec,
+		os.Getenv("KEDA_OPERATOR_IMAGE"), transform.ReplaceKedaOperatorImage, transform.ReplaceKedaOperatorImagePullPolicy, r.Scheme)...)
 
 	if len(instance.Spec.Operator.LogLevel) > 0 {
 		transforms = append(transforms, transform.ReplaceKedaOperatorLogLevel(instance.Spec.Operator.LogLevel, r.Scheme, logger))
@@ -452,6 +602,8 @@ func (r *KedaControllerReconciler) installController(ctx context.Context, logger
 		transforms = append(transforms, transform.ReplaceAffinity(instance.Spec.Operator.Affinity, r.Scheme))
 	}
 
+	transforms = append(transforms, r.highAvailabilityTransforms(instance.Spec.Operator.GenericDeploymentSpec)...)
+
 	if len(instance.Spec.Operator.PriorityClassName) > 0 {
 		transforms = append(transforms, transform.ReplacePriorityClassName(instance.Spec.Operator.PriorityClassName, r.Scheme))
 	}
@@ -460,6 +612,44 @@ func (r *KedaControllerReconciler) installController(ctx context.Context, logger
 		transforms = append(transforms, transform.ReplaceKedaOperatorResources(instance.Spec.Operator.Resources, r.Scheme))
 	}
 
+	transforms = append(transforms, runtimeTransformations(instance.Spec.Operator.GenericRuntimeSpec, "operator", r.Scheme, logger)...)
+
+	tlsTransforms, err := r.tlsProfileTransforms(ctx, logger, instance, "operator")
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, tlsTransforms...)
+
+	// the proxy goes first, so that env of the KedaController overrides it
+	if len(proxyEnv) > 0 {
+		transforms = append(transforms, transform.ReplaceKedaOperatorEnv(proxyEnv, nil, r.Scheme))
+	}
+
+	podIdentityTransforms, err := r.installPodIdentity(ctx, logger, instance)
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, podIdentityTransforms...)
+
+	envTransforms, err := r.envTransforms(ctx, instance, instance.Spec.Operator.GenericDeploymentSpec, transform.ReplaceKedaOperatorEnv)
+	if err != nil {
+		logger.Error(err, "Unable to read ConfigMaps and Secrets referenced by env of KEDA Controller")
+		return err
+	}
+	transforms = append(transforms, envTransforms...)
+
+	if instance.Spec.Operator.PodSecurityContext != nil {
+		transforms = append(transforms, transform.ReplacePodSecurityContext(instance.Spec.Operator.PodSecurityContext, r.Scheme))
+	}
+	if instance.Spec.Operator.ContainerSecurityContext != nil {
+		transforms = append(transforms, transform.ReplaceKedaOperatorSecurityContext(instance.Spec.Operator.ContainerSecurityContext, r.Scheme))
+	}
+	if instance.Spec.Operator.LivenessProbe != nil || instance.Spec.Operator.ReadinessProbe != nil {
+		transforms = append(transforms, transform.ReplaceKedaOperatorProbes(instance.Spec.Operator.LivenessProbe, instance.Spec.Operator.ReadinessProbe, r.Scheme))
+	}
+
+	transforms = append(transforms, podExtensionTransformations(instance.Spec.Operator.GenericDeploymentSpec, transform.AddKedaOperatorVolumeMounts, r.Scheme, logger)...)
+
 	// add arbitrary args defined by user
 	for i := range instance.Spec.Operator.Args {
 		i := i
@@ -471,14 +661,23 @@ func (r *KedaControllerReconciler) installController(ctx context.Context, logger
 		logger.Error(err, "Unable to transform KEDA Controller manifest")
 		return err
 	}
-	r.resourcesController = manifest
 
-	if err := r.resourcesController.Apply(); err != nil {
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
 		logger.Error(err, "Unable to install KEDA Controller")
 		return err
 	}
 
-	if runningOnOpenshift && !r.rotatorStarted {
+	// the Roles of namespaces no longer watched are removed once the ClusterRole grants the access again
+	if err := r.removeWatchedNamespacesRBAC(ctx, logger, rbacNamespaces(instance, watchedNamespaces)); err != nil {
+		return err
+	}
+
+	if err := r.ensurePodDisruptionBudget(ctx, logger, instance, "keda-operator", "keda-operator", instance.Spec.Operator.GenericDeploymentSpec); err != nil {
+		logger.Error(err, "Unable to reconcile PodDisruptionBudget", "PodDisruptionBudget.Name", "keda-operator")
+		return err
+	}
+
+	if provider == kedav1alpha1.CertificatesProviderOpenShiftServiceCA && !r.rotatorStarted {
 		err = rotator.AddRotator(r.mgr, &rotator.CertRotator{
 			SecretKey: types.NamespacedName{
 				Namespace: r.resourceNamespace,
@@ -503,41 +702,81 @@ func (r *KedaControllerReconciler) installController(ctx context.Context, logger
 	return nil
 }
 
-// installMonitoring install the controller resources for the monitoring stack
-func (r *KedaControllerReconciler) installMonitoring(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+// installMonitoring install the controller resources for the monitoring stack as configured in spec.monitoring,
+// it returns the monitoring mode reported in the status
+func (r *KedaControllerReconciler) installMonitoring(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) (kedav1alpha1.MonitoringMode, error) {
 	logger.Info("Reconciling monitoring resources")
 
+	// the dashboards are plain ConfigMaps which don't need the Prometheus Operator CRDs
+	if err := r.installDashboards(ctx, logger, instance); err != nil {
+		return "", err
+	}
+
 	// this works only if required CRDs are present
 	if !util.HasServiceMonitorCRD(ctx, logger, r.Client) {
 		logger.V(4).Info("ServiceMonitor CRD not found, skipping monitoring resources")
-		return nil
+		return monitoringModeWithoutCRDs(instance), nil
 	}
 	if !util.HasPodMonitorCRD(ctx, logger, r.Client) {
 		logger.V(4).Info("PodMonitor CRD not found, skipping monitoring resources")
-		return nil
+		return monitoringModeWithoutCRDs(instance), nil
 	}
 
+	monitoring := instance.Spec.Monitoring
 	transforms := []mf.Transformer{
 		transform.InjectOwner(instance),
 		transform.ReplaceAllNamespaces(instance.Namespace),
 	}
+	if len(monitoring.AdditionalLabels) > 0 {
+		transforms = append(transforms, transform.AddMonitorLabels(monitoring.AdditionalLabels))
+	}
+	if monitoring.Interval != "" || monitoring.ScrapeTimeout != "" || len(monitoring.Relabelings) > 0 {
+		relabelings, err := relabelingsToUnstructured(monitoring.Relabelings)
+		if err != nil {
+			return "", err
+		}
+		transforms = append(transforms, transform.ReplaceMonitorEndpoints(monitoring.Interval, monitoring.ScrapeTimeout, relabelings))
+	}
+	if monitoring.TLSConfig != nil || monitoring.BearerTokenSecret != nil {
+		tlsConfig, bearerTokenSecret, err := authorizationToUnstructured(monitoring)
+		if err != nil {
+			return "", err
+		}
+		transforms = append(transforms, transform.ReplaceMonitorAuthorization(tlsConfig, bearerTokenSecret))
+	}
 
 	manifest, err := r.resourcesMonitoring.Transform(transforms...)
 	if err != nil {
 		logger.Error(err, "Unable to transform monitoring resource manifests")
-		return err
+		return "", err
 	}
-	r.resourcesMonitoring = manifest
 
-	if err := r.resourcesMonitoring.Apply(); err != nil {
+	// the monitors of disabled components are removed instead
+	disabled := disabledMonitors(instance)
+	if err := manifest.Filter(disabled).Delete(); err != nil {
+		logger.Error(err, "Unable to remove monitoring resources of disabled components")
+		return "", err
+	}
+	if !monitoring.IsEnabled() {
+		if err := r.installPrometheusRule(ctx, logger, instance); err != nil {
+			return "", err
+		}
+		logger.Info("Monitoring is disabled, removed monitoring resources")
+		return kedav1alpha1.MonitoringModeDisabled, nil
+	}
+
+	if err := util.ApplyManifest(ctx, r.Client, manifest.Filter(mf.Not(disabled))); err != nil {
 		logger.Error(err, "Unable to install monitoring resources")
-		return err
+		return "", err
+	}
+	if err := r.installPrometheusRule(ctx, logger, instance); err != nil {
+		return "", err
 	}
 
-	return nil
+	return kedav1alpha1.MonitoringModePrometheusOperator, nil
 }
 
-func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController, status *kedav1alpha1.KedaControllerStatus) error {
 	logger.Info("Reconciling KEDA Metrics Server Deployment")
 
 	transforms := []mf.Transformer{
@@ -545,18 +784,17 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		transform.ReplaceAllNamespaces(instance.Namespace),
 	}
 
-	// Use alternate image spec if env var set
-	if controllerImage := os.Getenv("KEDA_METRICS_SERVER_IMAGE"); len(controllerImage) > 0 {
-		transforms = append(transforms, transform.ReplaceMetricsServerImage(controllerImage, r.Scheme))
-	}
+	transforms = append(transforms, imageTransformations(instance.Spec.ImageRegistryMirror, instance.Spec.MetricsServer.GenericDeploymentSpec,
+		os.Getenv("KEDA_METRICS_SERVER_IMAGE"), transform.ReplaceMetricsServerImage, transform.ReplaceMetricsServerImagePullPolicy, r.Scheme)...)
 
 	// on OpenShift 4.10 (kube 1.23) and earlier, the RuntimeDefault SeccompProfile won't validate against any SCC
 	if util.RunningOnOpenshift(ctx, logger, r.Client) && util.RunningOnClusterWithoutSeccompProfileDefault(logger, r.discoveryClient) {
 		transforms = append(transforms, transform.RemoveSeccompProfileFromMetricsServer(r.Scheme, logger))
 	}
 
-	// certificates rotation works only on Openshift due to openshift/service-ca-operator
-	if util.RunningOnOpenshift(ctx, logger, r.Client) {
+	provider := r.certificatesProvider(ctx, logger, instance)
+	switch provider {
+	case kedav1alpha1.CertificatesProviderOpenShiftServiceCA:
 		if err := r.ensureOpenshiftCABundleConfigMap(ctx, logger, instance); err != nil {
 			logger.Error(err, "Unable to check OpenShift CA Bundle ConfigMap is present")
 			return err
@@ -566,7 +804,7 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		newArgs := []string{"/certs/ca.crt", "/certs/ocp-tls.crt", "/certs/ocp-tls.key"}
 
 		serviceName := "keda-metrics-apiserver"
-		certsSecretName := serviceName + "-certs"
+		certsSecretName := metricsServerServiceCASecretName
 
 		transforms = append(transforms,
 			transform.EnsureCABundleInjectionForAPIService(injectCABundleAnnotation, injectCABundleAnnotationValue, r.Scheme),
@@ -574,8 +812,19 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 			transform.MetricsServerEnsureCertificatesVolume(caBundleConfigMapName, certsSecretName, r.Scheme),
 		)
 		transforms = append(transforms, transform.EnsurePathsToCertsInDeployment(newArgs, argsPrefixes, r.Scheme, logger)...)
-	} else {
-		logger.Info("Not running on OpenShift -> using only KEDA Operator generated self-signed cert for KEDA Metrics Server")
+	case kedav1alpha1.CertificatesProviderCertManager:
+		transforms = append(transforms,
+			transform.EnsureCABundleInjectionForAPIService(certManagerInjectCAFromAnnotation, instance.Namespace+"/"+certManagerCertificateName, r.Scheme),
+			transform.ReplaceCertificatesSecret(certManagerCertificateName, r.Scheme),
+		)
+	case kedav1alpha1.CertificatesProviderUserProvided:
+		certificatesTransforms, err := r.userProvidedCertificatesTransforms(ctx, logger, instance, "metricsserver")
+		if err != nil {
+			return err
+		}
+		transforms = append(transforms, certificatesTransforms...)
+	default:
+		logger.Info("Using only KEDA Operator generated self-signed cert for KEDA Metrics Server")
 	}
 
 	// Audit logging validation - configMap exists, logOutVolumeClaim validation
@@ -599,7 +848,7 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 
 		// --- Log output setup ---
 		// validation checks around logOutVolumeClaim and lifetime arguments
-		if err := validateAuditLogVolumeWithArgs(logOutVolumeClaim, instance.Spec.MetricsServer.AuditConfig.AuditLifetime); err != nil {
+		if err := util.ValidateAuditLogVolumeWithArgs(logOutVolumeClaim, instance.Spec.MetricsServer.AuditConfig.AuditLifetime); err != nil {
 			logger.Error(err, "unable to validate args for Audit logging")
 			return err
 		}
@@ -615,7 +864,10 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 				logger.Error(err, "unable to validate log output persistent volume")
 				return err
 			}
-			logVolumePath = "/var/audit-policy/log-" + time.Now().Format("2006.01.02-15:04")
+			if logVolumePath, err = r.auditLogMountPath(ctx, instance.Namespace); err != nil {
+				logger.Error(err, "unable to read the audit log path of Metrics Server")
+				return err
+			}
 			transforms = append(transforms, transform.EnsureAuditLogMount(logOutVolumeClaim, logVolumePath, r.Scheme))
 		}
 
@@ -640,6 +892,20 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		transforms = append(transforms, transform.AddPodAnnotations(instance.Spec.MetricsServer.PodAnnotations, r.Scheme))
 	}
 
+	// stamp the checksums of the mounted certificates into the pod template, so that the Deployment
+	// is rolled out whenever they change. They are computed from the live objects and stored in the status
+	// in the same pass, otherwise the first reconcile after an upgrade would roll out the Deployment twice
+	configMapSum, secretSum, err := r.metricsServerCertificatesChecksums(ctx, instance, provider)
+	if err != nil {
+		return err
+	}
+	status.ConfigMapDataSum = configMapSum
+	status.SecretDataSum = secretSum
+	transforms = append(transforms, transform.AddPodAnnotations(map[string]string{
+		configMapChecksumAnnotation: configMapSum,
+		secretChecksumAnnotation:    secretSum,
+	}, r.Scheme))
+
 	if len(instance.Spec.MetricsServer.PodLabels) > 0 {
 		transforms = append(transforms, transform.AddPodLabels(instance.Spec.MetricsServer.PodLabels, r.Scheme))
 	}
@@ -656,6 +922,8 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		transforms = append(transforms, transform.ReplaceAffinity(instance.Spec.MetricsServer.Affinity, r.Scheme))
 	}
 
+	transforms = append(transforms, r.highAvailabilityTransforms(instance.Spec.MetricsServer.GenericDeploymentSpec)...)
+
 	if len(instance.Spec.MetricsServer.PriorityClassName) > 0 {
 		transforms = append(transforms, transform.ReplacePriorityClassName(instance.Spec.MetricsServer.PriorityClassName, r.Scheme))
 	}
@@ -664,10 +932,65 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		transforms = append(transforms, transform.ReplaceMetricsServerResources(instance.Spec.MetricsServer.Resources, r.Scheme))
 	}
 
+	transforms = append(transforms, runtimeTransformations(instance.Spec.MetricsServer.GenericRuntimeSpec, "metricsserver", r.Scheme, logger)...)
+
+	if instance.Spec.MetricsServer.SecurePort != nil {
+		transforms = append(transforms, transform.ReplaceMetricsServerSecurePort(*instance.Spec.MetricsServer.SecurePort, r.Scheme, logger))
+	}
+	if len(instance.Spec.MetricsServer.GRPC.MetricsServiceAddress) > 0 {
+		transforms = append(transforms, transform.ReplaceMetricsServiceAddress(instance.Spec.MetricsServer.GRPC.MetricsServiceAddress, r.Scheme, logger))
+	}
+	if len(instance.Spec.MetricsServer.GRPC.MetricsServiceAuthority) > 0 {
+		transforms = append(transforms, transform.ReplaceMetricsServiceGRPCAuthority(instance.Spec.MetricsServer.GRPC.MetricsServiceAuthority, r.Scheme, logger))
+	}
+
+	tlsTransforms, err := r.tlsProfileTransforms(ctx, logger, instance, "metricsserver")
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, tlsTransforms...)
+
+	// the proxy goes first, so that env of the KedaController overrides it
+	if instance.Spec.Proxy.Components.MetricsServer {
+		proxyEnv, err := r.proxyEnv(ctx, logger, instance)
+		if err != nil {
+			return err
+		}
+		if len(proxyEnv) > 0 {
+			transforms = append(transforms, transform.ReplaceMetricsServerEnv(proxyEnv, nil, r.Scheme))
+		}
+	}
+
+	caTransforms, err := r.trustedCATransforms(ctx, logger, instance, "metricsserver", instance.Spec.MetricsServer.TrustedCASpec,
+		nil, nil, util.RunningOnOpenshift(ctx, logger, r.Client))
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, caTransforms...)
+
+	envTransforms, err := r.envTransforms(ctx, instance, instance.Spec.MetricsServer.GenericDeploymentSpec, transform.ReplaceMetricsServerEnv)
+	if err != nil {
+		logger.Error(err, "Unable to read ConfigMaps and Secrets referenced by env of KEDA Metrics Server")
+		return err
+	}
+	transforms = append(transforms, envTransforms...)
+
 	if !reflect.DeepEqual(instance.Spec.MetricsServer.AuditConfig, kedav1alpha1.AuditConfig{}) {
 		transforms = auditConfigTransformation(transforms, instance.Spec.MetricsServer.AuditConfig, r.Scheme, logger)
 	}
 
+	if instance.Spec.MetricsServer.PodSecurityContext != nil {
+		transforms = append(transforms, transform.ReplacePodSecurityContext(instance.Spec.MetricsServer.PodSecurityContext, r.Scheme))
+	}
+	if instance.Spec.MetricsServer.ContainerSecurityContext != nil {
+		transforms = append(transforms, transform.ReplaceMetricsServerSecurityContext(instance.Spec.MetricsServer.ContainerSecurityContext, r.Scheme))
+	}
+	if instance.Spec.MetricsServer.LivenessProbe != nil || instance.Spec.MetricsServer.ReadinessProbe != nil {
+		transforms = append(transforms, transform.ReplaceMetricsServerProbes(instance.Spec.MetricsServer.LivenessProbe, instance.Spec.MetricsServer.ReadinessProbe, r.Scheme))
+	}
+
+	transforms = append(transforms, podExtensionTransformations(instance.Spec.MetricsServer.GenericDeploymentSpec, transform.AddMetricsServerVolumeMounts, r.Scheme, logger)...)
+
 	// add arbitrary args defined by user
 	for i := range instance.Spec.MetricsServer.Args {
 		i := i
@@ -682,16 +1005,51 @@ func (r *KedaControllerReconciler) installMetricsServer(ctx context.Context, log
 		logger.Error(err, "Unable to transform Metrics Server manifest")
 		return err
 	}
-	r.resourcesMetrics = manifest
 
-	if err := r.resourcesMetrics.Apply(); err != nil {
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
 		logger.Error(err, "Unable to install Metrics Server")
 		return err
 	}
 
+	if err := r.ensurePodDisruptionBudget(ctx, logger, instance, "keda-metrics-apiserver", "keda-metrics-apiserver", instance.Spec.MetricsServer.GenericDeploymentSpec); err != nil {
+		logger.Error(err, "Unable to reconcile PodDisruptionBudget", "PodDisruptionBudget.Name", "keda-metrics-apiserver")
+		return err
+	}
+
 	return nil
 }
 
+// metricsServerCertificatesReferences returns the ConfigMaps and Secrets KEDA Metrics Server mounts its certificates from,
+// which depend on the provider of the certificates
+func metricsServerCertificatesReferences(instance *kedav1alpha1.KedaController, provider kedav1alpha1.CertificatesProvider) (configMaps, secrets []string) {
+	switch provider {
+	case kedav1alpha1.CertificatesProviderOpenShiftServiceCA:
+		return []string{caBundleConfigMapName}, []string{grpcClientCertsSecretName, metricsServerServiceCASecretName}
+	case kedav1alpha1.CertificatesProviderCertManager:
+		return nil, []string{certManagerCertificateName}
+	case kedav1alpha1.CertificatesProviderUserProvided:
+		return userProvidedReferences(instance.Spec.Certificates.UserProvided)
+	}
+	return nil, []string{grpcClientCertsSecretName}
+}
+
+// metricsServerCertificatesChecksums returns the checksums of the data of the ConfigMaps and of the Secrets KEDA Metrics
+// Server mounts its certificates from. Missing objects are checksummed as empty data, so that the annotations are always
+// stamped with a stable value
+func (r *KedaControllerReconciler) metricsServerCertificatesChecksums(ctx context.Context, instance *kedav1alpha1.KedaController,
+	provider kedav1alpha1.CertificatesProvider) (string, string, error) {
+	configMaps, secrets := metricsServerCertificatesReferences(instance, provider)
+	configMapSum, err := r.referencesChecksum(ctx, instance.Namespace, configMaps, nil)
+	if err != nil {
+		return "", "", err
+	}
+	secretSum, err := r.referencesChecksum(ctx, instance.Namespace, nil, secrets)
+	if err != nil {
+		return "", "", err
+	}
+	return configMapSum, secretSum, nil
+}
+
 func (r *KedaControllerReconciler) ensureOpenshiftCABundleConfigMap(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
 	logger.Info("Ensure ConfigMap for OpenShift CA bundle exists")
 
@@ -820,10 +1178,14 @@ func (r *KedaControllerReconciler) ensureMetricsServerAuditLogPolicyConfigMap(ct
 
 func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
 	logger.Info("Reconciling KEDA Admission Webhooks deployment")
+	watchNamespace, err := r.watchNamespace(ctx, logger, instance)
+	if err != nil {
+		return err
+	}
 	transforms := []mf.Transformer{
 		transform.InjectOwner(instance),
 		transform.ReplaceAllNamespaces(instance.Namespace),
-		transform.ReplaceWatchNamespace(instance.Spec.WatchNamespace, "keda-admission-webhooks", r.Scheme, logger),
+		transform.ReplaceWatchNamespace(watchNamespace, "keda-admission-webhooks", r.Scheme, logger),
 	}
 
 	// on OpenShift 4.10 (kube 1.23) and earlier, the RuntimeDefault SeccompProfile won't validate against any SCC
@@ -831,8 +1193,8 @@ func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context,
 		transforms = append(transforms, transform.RemoveSeccompProfileFromAdmissionWebhooks(r.Scheme, logger))
 	}
 
-	// certificates rotation works only on Openshift due to openshift/service-ca-operator
-	if util.RunningOnOpenshift(ctx, logger, r.Client) {
+	switch r.certificatesProvider(ctx, logger, instance) {
+	case kedav1alpha1.CertificatesProviderOpenShiftServiceCA:
 		serviceName := "keda-admission-webhooks"
 		certsSecretName := serviceName + "-certs"
 
@@ -841,12 +1203,22 @@ func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context,
 			transform.EnsureCertInjectionForService(serviceName, servingCertsAnnotation, certsSecretName),
 			transform.AdmissionWebhooksEnsureCertificatesVolume(caBundleConfigMapName, certsSecretName, r.Scheme),
 		)
+	case kedav1alpha1.CertificatesProviderCertManager:
+		transforms = append(transforms,
+			transform.EnsureCABundleInjectionForValidatingWebhookConfiguration(certManagerInjectCAFromAnnotation,
+				instance.Namespace+"/"+certManagerCertificateName, r.Scheme),
+			transform.ReplaceCertificatesSecret(certManagerCertificateName, r.Scheme),
+		)
+	case kedav1alpha1.CertificatesProviderUserProvided:
+		certificatesTransforms, err := r.userProvidedCertificatesTransforms(ctx, logger, instance, "admissionwebhooks")
+		if err != nil {
+			return err
+		}
+		transforms = append(transforms, certificatesTransforms...)
 	}
 
-	// Use alternate image spec if env var set
-	if controllerImage := os.Getenv("KEDA_ADMISSION_WEBHOOKS_IMAGE"); len(controllerImage) > 0 {
-		transforms = append(transforms, transform.ReplaceAdmissionWebhooksImage(controllerImage, r.Scheme))
-	}
+	transforms = append(transforms, imageTransformations(instance.Spec.ImageRegistryMirror, instance.Spec.AdmissionWebhooks.GenericDeploymentSpec,
+		os.Getenv("KEDA_ADMISSION_WEBHOOKS_IMAGE"), transform.ReplaceAdmissionWebhooksImage, transform.ReplaceAdmissionWebhooksImagePullPolicy, r.Scheme)...)
 
 	if len(instance.Spec.AdmissionWebhooks.LogLevel) > 0 {
 		transforms = append(transforms, transform.ReplaceAdmissionWebhooksLogLevel(instance.Spec.AdmissionWebhooks.LogLevel, r.Scheme, logger))
@@ -886,6 +1258,8 @@ func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context,
 		transforms = append(transforms, transform.ReplaceAffinity(instance.Spec.AdmissionWebhooks.Affinity, r.Scheme))
 	}
 
+	transforms = append(transforms, r.highAvailabilityTransforms(instance.Spec.AdmissionWebhooks.GenericDeploymentSpec)...)
+
 	if len(instance.Spec.AdmissionWebhooks.PriorityClassName) > 0 {
 		transforms = append(transforms, transform.ReplacePriorityClassName(instance.Spec.AdmissionWebhooks.PriorityClassName, r.Scheme))
 	}
@@ -894,6 +1268,51 @@ func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context,
 		transforms = append(transforms, transform.ReplaceAdmissionWebhooksResources(instance.Spec.AdmissionWebhooks.Resources, r.Scheme))
 	}
 
+	transforms = append(transforms, runtimeTransformations(instance.Spec.AdmissionWebhooks.GenericRuntimeSpec, "admissionwebhooks", r.Scheme, logger)...)
+
+	tlsTransforms, err := r.tlsProfileTransforms(ctx, logger, instance, "admissionwebhooks")
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, tlsTransforms...)
+
+	// the proxy goes first, so that env of the KedaController overrides it
+	if instance.Spec.Proxy.Components.AdmissionWebhooks {
+		proxyEnv, err := r.proxyEnv(ctx, logger, instance)
+		if err != nil {
+			return err
+		}
+		if len(proxyEnv) > 0 {
+			transforms = append(transforms, transform.ReplaceAdmissionWebhooksEnv(proxyEnv, nil, r.Scheme))
+		}
+	}
+
+	caTransforms, err := r.trustedCATransforms(ctx, logger, instance, "admissionwebhooks", instance.Spec.AdmissionWebhooks.TrustedCASpec,
+		nil, nil, util.RunningOnOpenshift(ctx, logger, r.Client))
+	if err != nil {
+		return err
+	}
+	transforms = append(transforms, caTransforms...)
+
+	envTransforms, err := r.envTransforms(ctx, instance, instance.Spec.AdmissionWebhooks.GenericDeploymentSpec, transform.ReplaceAdmissionWebhooksEnv)
+	if err != nil {
+		logger.Error(err, "Unable to read ConfigMaps and Secrets referenced by env of KEDA Admission Webhooks")
+		return err
+	}
+	transforms = append(transforms, envTransforms...)
+
+	if instance.Spec.AdmissionWebhooks.PodSecurityContext != nil {
+		transforms = append(transforms, transform.ReplacePodSecurityContext(instance.Spec.AdmissionWebhooks.PodSecurityContext, r.Scheme))
+	}
+	if instance.Spec.AdmissionWebhooks.ContainerSecurityContext != nil {
+		transforms = append(transforms, transform.ReplaceAdmissionWebhooksSecurityContext(instance.Spec.AdmissionWebhooks.ContainerSecurityContext, r.Scheme))
+	}
+	if instance.Spec.AdmissionWebhooks.LivenessProbe != nil || instance.Spec.AdmissionWebhooks.ReadinessProbe != nil {
+		transforms = append(transforms, transform.ReplaceAdmissionWebhooksProbes(instance.Spec.AdmissionWebhooks.LivenessProbe, instance.Spec.AdmissionWebhooks.ReadinessProbe, r.Scheme))
+	}
+
+	transforms = append(transforms, podExtensionTransformations(instance.Spec.AdmissionWebhooks.GenericDeploymentSpec, transform.AddAdmissionWebhooksVolumeMounts, r.Scheme, logger)...)
+
 	// add arbitrary args defined by user
 	for i := range instance.Spec.AdmissionWebhooks.Args {
 		i := i
@@ -905,13 +1324,17 @@ func (r *KedaControllerReconciler) installAdmissionWebhooks(ctx context.Context,
 		logger.Error(err, "Unable to transform KEDA Admission Webhooks manifest")
 		return err
 	}
-	r.resourcesWebhooks = manifest
 
-	if err := r.resourcesWebhooks.Apply(); err != nil {
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
 		logger.Error(err, "Unable to install KEDA Admission Webhooks")
 		return err
 	}
 
+	if err := r.ensurePodDisruptionBudget(ctx, logger, instance, "keda-admission", "keda-admission-webhooks", instance.Spec.AdmissionWebhooks.GenericDeploymentSpec); err != nil {
+		logger.Error(err, "Unable to reconcile PodDisruptionBudget", "PodDisruptionBudget.Name", "keda-admission")
+		return err
+	}
+
 	return nil
 }
 
@@ -921,6 +1344,21 @@ func isInteresting(request reconcile.Request, kedaControllerResourceNamespace st
 	return request.Name == kedaControllerResourceName && request.Namespace == kedaControllerResourceNamespace
 }
 
+// auditLogMountPath returns the path the audit log volume is mounted at in KEDA Metrics Server. The path carries
+// the time logging to the volume started, so the one of the live Deployment is kept, otherwise every reconcile
+// in another minute would roll out the Deployment again
+func (r *KedaControllerReconciler) auditLogMountPath(ctx context.Context, namespace string) (string, error) {
+	deploy := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: "keda-metrics-apiserver", Namespace: namespace}, deploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+	} else if mountPath := transform.AuditLogMountPath(deploy); mountPath != "" {
+		return mountPath, nil
+	}
+	return "/var/audit-policy/log-" + time.Now().Format("2006.01.02-15:04"), nil
+}
+
 // auditConfigTransformation is support function for transforming basic audit
 // flags. audit-log-path and audit-policy-file are transformed separately because of
 // different complexity. Returns transforms ([]mf.Transformer type) after all
@@ -941,40 +1379,69 @@ func auditConfigTransformation(t []mf.Transformer, ac kedav1alpha1.AuditConfig,
 	return t
 }
 
-// validateAuditLogVolumeWithArgs validates whether Volume can exist with lifetime
-// arguments. If name is empty (no volume given) -> validate lifetime args are
-// not given otherwise lt args would be useless for stdout logging.
-func validateAuditLogVolumeWithArgs(name string, ltArgs kedav1alpha1.AuditLifetime) error {
-	if name == "" {
-		var maxage int
-		var maxbackup int
-		var maxsize int
-		var err error
-
-		// check if lifetime args are given -> this would be error
-		if ltArgs.MaxAge != "" {
-			maxage, err = strconv.Atoi(ltArgs.MaxAge)
-			if err != nil {
-				return fmt.Errorf("bad conversion of string in audit flag maxAge")
-			}
-		}
-		if ltArgs.MaxBackup != "" {
-			maxbackup, err = strconv.Atoi(ltArgs.MaxBackup)
-			if err != nil {
-				return fmt.Errorf("bad conversion of string in audit flag maxBackup")
-			}
-		}
-		if ltArgs.MaxSize != "" {
-			maxsize, err = strconv.Atoi(ltArgs.MaxSize)
-			if err != nil {
-				return fmt.Errorf("bad conversion of string in audit flag maxSize")
-			}
-		}
-		if maxage >= 1 || maxbackup >= 1 || maxsize >= 1 {
-			return fmt.Errorf("bad flag combination - don't use lifetime arguments when logging to stdout")
-		}
+// imageTransformations returns the transformations setting the image of a KEDA component. The image set in the
+// KedaController takes precedence over envImage, the image set by the operator environment variable, which takes
+// precedence over the default image. The registry mirror is applied to envImage and the default image only
+func imageTransformations(mirror string, spec kedav1alpha1.GenericDeploymentSpec, envImage string,
+	replaceImage func(string, *runtime.Scheme) mf.Transformer, replacePullPolicy func(corev1.PullPolicy, *runtime.Scheme) mf.Transformer,
+	scheme *runtime.Scheme) []mf.Transformer {
+	var t []mf.Transformer
+	if len(envImage) > 0 {
+		t = append(t, replaceImage(envImage, scheme))
 	}
-	return nil
+	if len(mirror) > 0 {
+		t = append(t, transform.ReplaceImageRegistry(mirror, scheme))
+	}
+	if len(spec.Image) > 0 {
+		t = append(t, replaceImage(spec.Image, scheme))
+	}
+	if len(spec.ImagePullPolicy) > 0 {
+		t = append(t, replacePullPolicy(spec.ImagePullPolicy, scheme))
+	}
+	if len(spec.ImagePullSecrets) > 0 {
+		t = append(t, transform.ReplaceImagePullSecrets(spec.ImagePullSecrets, scheme))
+	}
+	return t
+}
+
+// podExtensionTransformations returns the transformations adding the user-defined volumes, volume mounts, init
+// containers and sidecars to the pod of a KEDA component, they come last so that the items the component
+// depends on are already present and are kept in case of a conflict
+func podExtensionTransformations(spec kedav1alpha1.GenericDeploymentSpec,
+	addVolumeMounts func([]corev1.VolumeMount, *runtime.Scheme, logr.Logger) mf.Transformer, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
+	var t []mf.Transformer
+	if len(spec.Volumes) > 0 {
+		t = append(t, transform.AddVolumes(spec.Volumes, scheme, logger))
+	}
+	if len(spec.VolumeMounts) > 0 {
+		t = append(t, addVolumeMounts(spec.VolumeMounts, scheme, logger))
+	}
+	if len(spec.InitContainers) > 0 {
+		t = append(t, transform.AddInitContainers(spec.InitContainers, scheme, logger))
+	}
+	if len(spec.Sidecars) > 0 {
+		t = append(t, transform.AddSidecarContainers(spec.Sidecars, scheme, logger))
+	}
+	return t
+}
+
+// runtimeTransformations returns the transformations rendering the runtime settings of a KEDA component
+// to its flags and environment variables, resource is one of 'operator', 'metricsserver' and 'admissionwebhooks'
+func runtimeTransformations(spec kedav1alpha1.GenericRuntimeSpec, resource string, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
+	var t []mf.Transformer
+	if spec.HTTPTimeout != nil {
+		t = append(t, transform.ReplaceHTTPTimeout(spec.HTTPTimeout.Duration, resource, scheme))
+	}
+	if spec.KubeAPIQPS != nil {
+		t = append(t, transform.ReplaceKubeAPIQPS(*spec.KubeAPIQPS, resource, scheme, logger))
+	}
+	if spec.KubeAPIBurst != nil {
+		t = append(t, transform.ReplaceKubeAPIBurst(*spec.KubeAPIBurst, resource, scheme, logger))
+	}
+	if spec.ProfilingBindAddress != "" {
+		t = append(t, transform.ReplaceProfilingBindAddress(spec.ProfilingBindAddress, resource, scheme, logger))
+	}
+	return t
 }
 
 // checkAuditLogVolumeExists checks whether PersistentVolumeClaim given exists
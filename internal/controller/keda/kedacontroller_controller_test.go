@@ -27,8 +27,17 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
 )
@@ -349,9 +358,7 @@ var _ = Describe("Testing audit flags", func() {
 
 	When("Manipulating parameters", func() {
 
-		// TODO(jkyros): come back to refactor this test, it doesn't proeprly reset between test cases, and
-		// if it did, it would be failing, because it's currently impossible to deconfigure audit because of
-		// how our manifestival code only operates if the settings are different from default/empty.
+		// TODO(jkyros): come back to refactor this test, it doesn't proeprly reset between test cases
 		BeforeEach(func() {
 			scheme = k8sManager.GetScheme()
 			dep = &appsv1.Deployment{}
@@ -408,6 +415,248 @@ var _ = Describe("Testing audit flags", func() {
 			}
 
 		})
+
+		Context("to write the audit log to a volume", func() {
+			It("keeps the audit log path between reconciles", func() {
+				const caseName = "writes the audit log to a volume"
+				pvc := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "audit-log", Namespace: namespace},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+					},
+				}
+				Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, pvc))).To(Succeed())
+
+				manifest, err := changeAttribute(manifest, "auditLogOutputVolumeClaim", pvc.Name, scheme, caseName)
+				Expect(err).To(BeNil())
+				Expect(manifest.Apply()).To(Succeed())
+				Eventually(func() error {
+					return deploymentHasRolledOut(metricsServerName, namespace, caseName)
+				}, timeout, interval).Should(Succeed())
+
+				metricsServerArgs := func() []string {
+					deploy := &appsv1.Deployment{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: metricsServerName, Namespace: namespace}, deploy)).To(Succeed())
+					return deploy.Spec.Template.Spec.Containers[0].Args
+				}
+				reconcile := func() {
+					_, _ = kedaControllerReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}})
+				}
+
+				reconcile()
+				args := metricsServerArgs()
+				Expect(args).To(ContainElement(HavePrefix("--audit-log-path=/var/audit-policy/log-")))
+				reconcile()
+				Expect(metricsServerArgs()).To(Equal(args))
+
+				// a path from a reconcile in another minute is kept as well
+				deploy := &appsv1.Deployment{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: metricsServerName, Namespace: namespace}, deploy)).To(Succeed())
+				const earlierPath = "/var/audit-policy/log-2024.01.01-00:00"
+				container := &deploy.Spec.Template.Spec.Containers[0]
+				for i := range container.VolumeMounts {
+					if container.VolumeMounts[i].Name == "audit-log" {
+						container.VolumeMounts[i].MountPath = earlierPath
+					}
+				}
+				for i := range container.Args {
+					if strings.HasPrefix(container.Args[i], "--audit-log-path=") {
+						container.Args[i] = "--audit-log-path=" + earlierPath + "/" + pvc.Name
+					}
+				}
+				Expect(k8sClient.Update(ctx, deploy)).To(Succeed())
+				args = metricsServerArgs()
+				reconcile()
+				Expect(metricsServerArgs()).To(Equal(args))
+			})
+		})
+	})
+})
+
+var _ = Describe("Removing settings from KedaController", func() {
+	const (
+		deploymentName       = "keda-operator"
+		containerName        = "keda-operator"
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+	)
+
+	var (
+		ctx      = context.Background()
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 250
+		scheme   *runtime.Scheme
+		manifest mf.Manifest
+		err      error
+	)
+
+	BeforeEach(func() {
+		scheme = k8sManager.GetScheme()
+		manifest, err = createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+	})
+
+	getOperatorDeployment := func() *appsv1.Deployment {
+		dep := &appsv1.Deployment{}
+		u, err := getObject(ctx, "Deployment", deploymentName, namespace, k8sClient)
+		Expect(err).To(BeNil())
+		Expect(scheme.Convert(u, dep, nil)).To(Succeed())
+		return dep
+	}
+
+	variants := []struct {
+		attr    string
+		value   string
+		present func(dep *appsv1.Deployment) bool
+	}{
+		{
+			attr:  "podLabels",
+			value: "removal-test=label",
+			present: func(dep *appsv1.Deployment) bool {
+				_, found := dep.Spec.Template.Labels["removal-test"]
+				return found
+			},
+		},
+		{
+			attr:  "nodeSelector",
+			value: "removal-test=node",
+			present: func(dep *appsv1.Deployment) bool {
+				_, found := dep.Spec.Template.Spec.NodeSelector["removal-test"]
+				return found
+			},
+		},
+		{
+			attr:  "args",
+			value: "--kube-api-burst=42",
+			present: func(dep *appsv1.Deployment) bool {
+				_, err := getDepArg(dep, "--kube-api-burst=", containerName)
+				return err == nil
+			},
+		},
+	}
+
+	for _, variant := range variants {
+		variant := variant
+		caseName := fmt.Sprintf("Should restore the default Deployment once '%s' is removed", variant.attr)
+		It(caseName, func() {
+			By(fmt.Sprintf("Setting operator %s to %s in kedaController manifest", variant.attr, variant.value))
+			setManifest, err := changeAttribute(manifest, variant.attr, variant.value, scheme, caseName+" set")
+			Expect(err).To(BeNil())
+			Expect(setManifest.Apply()).To(Succeed())
+			Eventually(func() error {
+				return deploymentHasRolledOut(deploymentName, namespace, caseName+" set")
+			}, timeout, interval).Should(Succeed())
+			Expect(variant.present(getOperatorDeployment())).To(BeTrue())
+
+			By(fmt.Sprintf("Removing operator %s from kedaController manifest", variant.attr))
+			removedManifest, err := changeAttribute(manifest, variant.attr, "", scheme, caseName+" removed")
+			Expect(err).To(BeNil())
+			Expect(removedManifest.Apply()).To(Succeed())
+			Eventually(func() error {
+				return deploymentHasRolledOut(deploymentName, namespace, caseName+" removed")
+			}, timeout, interval).Should(Succeed())
+			Expect(variant.present(getOperatorDeployment())).To(BeFalse())
+		})
+	}
+})
+
+var _ = Describe("Disabling KEDA components", func() {
+	const (
+		deploymentName       = "keda-admission"
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+	)
+
+	var (
+		ctx      = context.Background()
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 250
+		scheme   *runtime.Scheme
+		manifest mf.Manifest
+		err      error
+	)
+
+	BeforeEach(func() {
+		scheme = k8sManager.GetScheme()
+		manifest, err = createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+	})
+
+	It("Should remove the admission webhooks once they are disabled and install them again once enabled", func() {
+		By("Disabling admission webhooks in kedaController manifest")
+		disabledManifest, err := changeAttribute(manifest, "admissionWebhooksEnabled", "false", scheme, "admission webhooks disabled")
+		Expect(err).To(BeNil())
+		Expect(disabledManifest.Apply()).To(Succeed())
+		Eventually(func() error {
+			_, err := getObject(ctx, "Deployment", deploymentName, namespace, k8sClient)
+			return err
+		}, timeout, interval).ShouldNot(Succeed())
+
+		By("Enabling admission webhooks in kedaController manifest")
+		enabledManifest, err := changeAttribute(manifest, "admissionWebhooksEnabled", "", scheme, "admission webhooks enabled")
+		Expect(err).To(BeNil())
+		Expect(enabledManifest.Apply()).To(Succeed())
+		Eventually(func() error {
+			return deploymentHasRolledOut(deploymentName, namespace, "admission webhooks enabled")
+		}, timeout, interval).Should(Succeed())
+	})
+})
+
+var _ = Describe("Stamping the certificates checksums of KEDA Metrics Server", func() {
+	const (
+		metricsServerName    = "keda-metrics-apiserver"
+		name                 = "keda"
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+	)
+
+	var (
+		ctx      = context.Background()
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 250
+	)
+
+	It("Should stamp the checksums stored in the status in the same reconcile", func() {
+		manifest, err := createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+		Expect(manifest.Apply()).To(Succeed())
+		Eventually(func() error {
+			return deploymentHasRolledOut(metricsServerName, namespace, "certificates checksums")
+		}, timeout, interval).Should(Succeed())
+
+		key := types.NamespacedName{Name: name, Namespace: namespace}
+		podAnnotations := func() map[string]string {
+			deploy := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: metricsServerName, Namespace: namespace}, deploy)).To(Succeed())
+			return deploy.Spec.Template.Annotations
+		}
+
+		_, _ = kedaControllerReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		instance := &kedav1alpha1.KedaController{}
+		Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+		Expect(instance.Status.ConfigMapDataSum).NotTo(BeEmpty())
+		Expect(instance.Status.SecretDataSum).NotTo(BeEmpty())
+
+		annotations := podAnnotations()
+		Expect(annotations).To(HaveKeyWithValue(configMapChecksumAnnotation, instance.Status.ConfigMapDataSum))
+		Expect(annotations).To(HaveKeyWithValue(secretChecksumAnnotation, instance.Status.SecretDataSum))
+
+		_, _ = kedaControllerReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(podAnnotations()).To(Equal(annotations))
+
+		By("rotating the mounted certificates Secret")
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: grpcClientCertsSecretName, Namespace: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, secret))).To(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: grpcClientCertsSecretName, Namespace: namespace}, secret)).To(Succeed())
+		secret.Data = map[string][]byte{"tls.crt": []byte(time.Now().String())}
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		Eventually(func() string {
+			return podAnnotations()[secretChecksumAnnotation]
+		}, timeout, interval).ShouldNot(Equal(annotations[secretChecksumAnnotation]))
+		Expect(podAnnotations()[configMapChecksumAnnotation]).To(Equal(annotations[configMapChecksumAnnotation]))
 	})
 })
 
@@ -455,11 +704,46 @@ func changeAttribute(manifest mf.Manifest, attr string, value string, scheme *ru
 			kedaControllerInstance.Namespace = value
 		case "logLevel":
 			kedaControllerInstance.Spec.Operator.LogLevel = value
+		// operator settings that can be removed again by passing an empty value,
+		// maps are passed as a single "key=value" pair
+		case "podLabels":
+			kedaControllerInstance.Spec.Operator.PodLabels = keyValueToMap(value)
+		case "nodeSelector":
+			kedaControllerInstance.Spec.Operator.NodeSelector = keyValueToMap(value)
+		case "args":
+			kedaControllerInstance.Spec.Operator.Args = nil
+			if value != "" {
+				kedaControllerInstance.Spec.Operator.Args = []string{value}
+			}
 		// TODO(jkyros): this breaks pattern with the rest of these cases but multiple operands have
 		// the same field, but we kind of bolted the admission tests on here without doing a refactor and
 		// this makes it work for now
 		case "logLevel-admission":
 			kedaControllerInstance.Spec.AdmissionWebhooks.LogLevel = value
+		case "metricsServerEnabled":
+			kedaControllerInstance.Spec.MetricsServer.Enabled = nil
+			if value != "" {
+				enabled := value == "true"
+				kedaControllerInstance.Spec.MetricsServer.Enabled = &enabled
+			}
+		case "admissionWebhooksEnabled":
+			kedaControllerInstance.Spec.AdmissionWebhooks.Enabled = nil
+			if value != "" {
+				enabled := value == "true"
+				kedaControllerInstance.Spec.AdmissionWebhooks.Enabled = &enabled
+			}
+		// comma-separated namespaces, an empty value watches all namespaces again
+		case "watchNamespaces":
+			kedaControllerInstance.Spec.WatchNamespaces = nil
+			if value != "" {
+				kedaControllerInstance.Spec.WatchNamespaces = strings.Split(value, ",")
+			}
+		// a single label 'key=value' the watched namespaces need to have, an empty value removes the selector
+		case "namespaceSelector":
+			kedaControllerInstance.Spec.NamespaceSelector = nil
+			if key, labelValue, found := strings.Cut(value, "="); found {
+				kedaControllerInstance.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{key: labelValue}}
+			}
 		// metricsServer audit arguments
 		case "auditLogFormat":
 			kedaControllerInstance.Spec.MetricsServer.AuditConfig.LogFormat = value
@@ -469,6 +753,10 @@ func changeAttribute(manifest mf.Manifest, attr string, value string, scheme *ru
 			kedaControllerInstance.Spec.MetricsServer.AuditConfig.AuditLifetime.MaxBackup = value
 		case "auditLogMaxSize":
 			kedaControllerInstance.Spec.MetricsServer.AuditConfig.AuditLifetime.MaxSize = value
+		// the audit log is only written to the volume with a policy
+		case "auditLogOutputVolumeClaim":
+			kedaControllerInstance.Spec.MetricsServer.AuditConfig.LogOutputVolumeClaim = value
+			kedaControllerInstance.Spec.MetricsServer.AuditConfig.Policy.Rules = []auditv1.PolicyRule{{Level: auditv1.LevelMetadata}}
 		default:
 			return errors.New("Not a valid attribute")
 		}
@@ -478,6 +766,14 @@ func changeAttribute(manifest mf.Manifest, attr string, value string, scheme *ru
 	return manifest.Transform(transformer)
 }
 
+func keyValueToMap(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	key, val, _ := strings.Cut(value, "=")
+	return map[string]string{key: val}
+}
+
 // deploymentHasRolledOut waits for the specified deployment to possess the specified annotation
 //
 //nolint:unparam
@@ -496,3 +792,410 @@ func deploymentHasRolledOut(deploymentName string, namespace string, deploymentA
 	}
 	return fmt.Errorf("Deployment has not rolled out, annotation is still '%s'", testcase)
 }
+
+var _ = Describe("Authenticating Prometheus scalers against OpenShift Thanos Querier", func() {
+	const namespace = "keda"
+
+	var (
+		ctx         = context.Background()
+		timeout     = time.Second * 60
+		interval    = time.Millisecond * 250
+		cancelCache context.CancelFunc
+		reconciler  *KedaControllerReconciler
+		instance    *kedav1alpha1.KedaController
+		status      *kedav1alpha1.KedaControllerStatus
+	)
+
+	BeforeEach(func() {
+		scheme := k8sManager.GetScheme()
+
+		// the manager of the operator caches only the install namespace, the same applies to the client of this reconciler
+		var cacheCtx context.Context
+		cacheCtx, cancelCache = context.WithCancel(ctx)
+		namespacedCache, err := cache.New(cfg, cache.Options{Scheme: scheme, DefaultNamespaces: map[string]cache.Config{namespace: {}}})
+		Expect(err).To(BeNil())
+		go func() {
+			defer GinkgoRecover()
+			Expect(namespacedCache.Start(cacheCtx)).To(Succeed())
+		}()
+		Expect(namespacedCache.WaitForCacheSync(cacheCtx)).To(BeTrue())
+		cl, err := client.New(cfg, client.Options{Scheme: scheme, Cache: &client.CacheOptions{Reader: namespacedCache}})
+		Expect(err).To(BeNil())
+		reconciler = &KedaControllerReconciler{Client: cl, Scheme: scheme, resourceNamespace: namespace}
+
+		// a KedaController of another name is ignored by the running reconciler, it only owns the created resources here
+		instance = &kedav1alpha1.KedaController{ObjectMeta: metav1.ObjectMeta{Name: "keda-openshift-monitoring", Namespace: namespace}}
+		instance.Spec.OpenShiftMonitoring.Enabled = true
+		Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+		status = &kedav1alpha1.KedaControllerStatus{}
+	})
+
+	AfterEach(func() {
+		caBundle := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: caBundleConfigMapName, Namespace: namespace}}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, caBundle))).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, instance))).To(Succeed())
+		cancelCache()
+	})
+
+	thanosQuerierCondition := func() *metav1.Condition {
+		Expect(reconciler.installOpenshiftMonitoring(ctx, reconciler.Log, instance, status)).To(Succeed())
+		return status.GetCondition(kedav1alpha1.ConditionOpenShiftMonitoringReady)
+	}
+
+	It("Should report a cluster without Thanos Querier", func() {
+		service := &corev1.Service{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: thanosQuerierServiceName, Namespace: thanosQuerierNamespace}, service)
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		if err == nil {
+			Expect(k8sClient.Delete(ctx, service)).To(Succeed())
+		}
+
+		condition := thanosQuerierCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("ThanosQuerierNotFound"))
+	})
+
+	It("Should find Thanos Querier outside of the cached install namespace", func() {
+		monitoringNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierNamespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, monitoringNamespace))).To(Succeed())
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierServiceName, Namespace: thanosQuerierNamespace},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "web", Port: 9091}}},
+		}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, service))).To(Succeed())
+
+		By("waiting for the service CA bundle to be injected")
+		condition := thanosQuerierCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("CABundleNotInjected"))
+
+		triggerAuthentication := &unstructured.Unstructured{}
+		triggerAuthentication.SetGroupVersionKind(clusterTriggerAuthenticationGVK)
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: defaultThanosQuerierTriggerAuthName}, triggerAuthentication)).To(Succeed())
+
+		By("injecting the service CA bundle")
+		caBundle := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: caBundleConfigMapName, Namespace: namespace}, caBundle)).To(Succeed())
+		caBundle.Data = map[string]string{serviceCABundleKey: "-----BEGIN CERTIFICATE-----"}
+		Expect(k8sClient.Update(ctx, caBundle)).To(Succeed())
+		Eventually(func() metav1.ConditionStatus {
+			return thanosQuerierCondition().Status
+		}, timeout, interval).Should(Equal(metav1.ConditionTrue))
+
+		By("disabling the authentication again")
+		instance.Spec.OpenShiftMonitoring.Enabled = false
+		condition = thanosQuerierCondition()
+		Expect(condition.Reason).To(Equal(kedav1alpha1.ReasonDisabled))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: defaultThanosQuerierTriggerAuthName}, triggerAuthentication)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Limiting the RBAC of KEDA to the watched namespaces", func() {
+	const (
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+	)
+
+	var (
+		ctx               = context.Background()
+		timeout           = time.Second * 60
+		interval          = time.Millisecond * 250
+		scheme            *runtime.Scheme
+		manifest          mf.Manifest
+		err               error
+		watchedNamespaces = []string{"keda-watched-a", "keda-watched-b"}
+	)
+
+	BeforeEach(func() {
+		scheme = k8sManager.GetScheme()
+		manifest, err = createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+		for _, name := range watchedNamespaces {
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}))).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		Expect(manifest.Apply()).To(Succeed())
+	})
+
+	clusterRoleRules := func() []rbacv1.PolicyRule {
+		clusterRole := &rbacv1.ClusterRole{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: kedaOperatorClusterRoleName}, clusterRole); err != nil {
+			return nil
+		}
+		return clusterRole.Rules
+	}
+	roleNamespaces := func() []string {
+		list := &rbacv1.RoleList{}
+		Expect(k8sClient.List(ctx, list, client.MatchingLabels{watchedNamespaceRBACLabel: watchedNamespaceRBACLabelValue})).To(Succeed())
+		var namespaces []string
+		for _, role := range list.Items {
+			namespaces = append(namespaces, role.Namespace)
+		}
+		return namespaces
+	}
+	applyWatchNamespaces := func(value string) {
+		limited, err := changeAttribute(manifest, "watchNamespaces", value, scheme, "watch namespaces "+value)
+		Expect(err).To(BeNil())
+		Expect(limited.Apply()).To(Succeed())
+	}
+
+	It("Should grant the namespaced rules by Roles and prune them when watchNamespaces shrinks", func() {
+		clusterRules, namespacedRules, err := kedaControllerReconciler.operatorPolicyRules()
+		Expect(err).To(BeNil())
+		pristine := kedaControllerReconciler.resourcesController.Filter(mf.ByKind("ClusterRole"), mf.ByName(kedaOperatorClusterRoleName)).Resources()
+		Expect(pristine).To(HaveLen(1))
+		allRules, found, err := unstructured.NestedSlice(pristine[0].Object, "rules")
+		Expect(err).To(BeNil())
+		Expect(found).To(BeTrue())
+
+		By("watching two namespaces")
+		applyWatchNamespaces(strings.Join(watchedNamespaces, ","))
+		Eventually(roleNamespaces, timeout, interval).Should(ConsistOf(namespace, "keda-watched-a", "keda-watched-b"))
+		Eventually(func() int { return len(clusterRoleRules()) }, timeout, interval).Should(Equal(len(clusterRules)))
+		for _, rule := range clusterRoleRules() {
+			Expect(rule.Resources).NotTo(ContainElement("secrets"))
+		}
+		for _, ns := range []string{namespace, "keda-watched-a", "keda-watched-b"} {
+			key := types.NamespacedName{Name: watchedNamespaceRBACName, Namespace: ns}
+			role := &rbacv1.Role{}
+			Expect(k8sClient.Get(ctx, key, role)).To(Succeed())
+			Expect(role.Rules).To(HaveLen(len(namespacedRules)))
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, key, roleBinding)).To(Succeed())
+			Expect(roleBinding.RoleRef.Name).To(Equal(watchedNamespaceRBACName))
+			Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: kedaOperatorServiceAccount, Namespace: namespace}))
+		}
+
+		By("watching one namespace less")
+		applyWatchNamespaces("keda-watched-a")
+		Eventually(roleNamespaces, timeout, interval).Should(ConsistOf(namespace, "keda-watched-a"))
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: watchedNamespaceRBACName, Namespace: "keda-watched-b"}, &rbacv1.RoleBinding{})
+		}, timeout, interval).ShouldNot(Succeed())
+
+		By("watching all namespaces again")
+		applyWatchNamespaces("")
+		Eventually(roleNamespaces, timeout, interval).Should(BeEmpty())
+		Eventually(func() int { return len(clusterRoleRules()) }, timeout, interval).Should(Equal(len(allRules)))
+	})
+})
+
+var _ = Describe("Watching the namespaces chosen by namespaceSelector", func() {
+	const (
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+		selectorLabel        = "keda.sh/watched"
+	)
+
+	var (
+		ctx                = context.Background()
+		timeout            = time.Second * 60
+		interval           = time.Millisecond * 250
+		scheme             *runtime.Scheme
+		manifest           mf.Manifest
+		err                error
+		selectedNamespaces = []string{"keda-selected-a", "keda-selected-b"}
+	)
+
+	setLabel := func(name string, value string) {
+		ns := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, ns)).To(Succeed())
+		labels := ns.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		if value == "" {
+			delete(labels, selectorLabel)
+		} else {
+			labels[selectorLabel] = value
+		}
+		ns.SetLabels(labels)
+		Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		scheme = k8sManager.GetScheme()
+		manifest, err = createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+		for _, name := range selectedNamespaces {
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}))).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		for _, name := range selectedNamespaces {
+			setLabel(name, "")
+		}
+		Expect(manifest.Apply()).To(Succeed())
+	})
+
+	watchNamespace := func() string {
+		deployment := &appsv1.Deployment{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: "keda-operator", Namespace: namespace}, deployment); err != nil {
+			return err.Error()
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, env := range container.Env {
+				if env.Name == "WATCH_NAMESPACE" {
+					return env.Value
+				}
+			}
+		}
+		return ""
+	}
+	applyNamespaceSelector := func(value string) {
+		selected, err := changeAttribute(manifest, "namespaceSelector", value, scheme, "namespace selector "+value)
+		Expect(err).To(BeNil())
+		Expect(selected.Apply()).To(Succeed())
+	}
+
+	It("Should fall back to the install namespace when no namespace matches", func() {
+		applyNamespaceSelector(selectorLabel + "=true")
+		Eventually(watchNamespace, timeout, interval).Should(Equal(namespace))
+	})
+
+	It("Should watch the namespaces with the label and follow their relabeling", func() {
+		By("labeling a namespace before the selector is set")
+		setLabel("keda-selected-a", "true")
+		applyNamespaceSelector(selectorLabel + "=true")
+		Eventually(watchNamespace, timeout, interval).Should(Equal("keda-selected-a"))
+
+		By("labeling another namespace")
+		setLabel("keda-selected-b", "true")
+		Eventually(watchNamespace, timeout, interval).Should(Equal("keda-selected-a,keda-selected-b"))
+
+		By("relabeling the first namespace")
+		setLabel("keda-selected-a", "false")
+		Eventually(watchNamespace, timeout, interval).Should(Equal("keda-selected-b"))
+
+		By("removing the label of the last matching namespace")
+		setLabel("keda-selected-b", "")
+		Eventually(watchNamespace, timeout, interval).Should(Equal(namespace))
+	})
+})
+
+var _ = Describe("Reporting the state of KEDA in the KedaController status", func() {
+	const (
+		name                 = "keda"
+		namespace            = "keda"
+		kedaManifestFilepath = "../../../config/samples/keda_v1alpha1_kedacontroller.yaml"
+	)
+
+	var (
+		ctx      = context.Background()
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 250
+		scheme   *runtime.Scheme
+		manifest mf.Manifest
+		err      error
+	)
+
+	BeforeEach(func() {
+		scheme = k8sManager.GetScheme()
+		manifest, err = createManifest(kedaManifestFilepath, k8sClient)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(manifest.Apply()).To(Succeed())
+	})
+
+	getStatus := func() (kedav1alpha1.KedaControllerStatus, int64) {
+		instance := &kedav1alpha1.KedaController{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, instance)).To(Succeed())
+		return instance.Status, instance.Generation
+	}
+	// there is no controller rolling out the Deployments in the test environment, so their status is set here
+	markRolledOut := func(deploymentName string) error {
+		deploy := &appsv1.Deployment{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: namespace}, deploy); err != nil {
+			return err
+		}
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+		deploy.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deploy.Generation,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			ReadyReplicas:      replicas,
+			AvailableReplicas:  replicas,
+		}
+		return k8sClient.Status().Update(ctx, deploy)
+	}
+
+	It("Should report the conditions of a rolled out installation", func() {
+		// the external metrics APIService isn't reachable in the test environment, so only the Deployments are checked
+		withoutMetricsServer, err := changeAttribute(manifest, "metricsServerEnabled", "false", scheme, "status of a rolled out installation")
+		Expect(err).To(BeNil())
+		Expect(withoutMetricsServer.Apply()).To(Succeed())
+
+		Eventually(func() error {
+			for _, deploymentName := range []string{"keda-operator", "keda-admission"} {
+				if err := markRolledOut(deploymentName); err != nil {
+					return err
+				}
+			}
+			status, generation := getStatus()
+			if status.Phase != kedav1alpha1.PhaseInstallSucceeded || status.ObservedGeneration != generation {
+				return fmt.Errorf("KedaController of generation %d is in phase '%s' for generation %d", generation, status.Phase, status.ObservedGeneration)
+			}
+			return nil
+		}, timeout, interval).Should(Succeed())
+
+		status, generation := getStatus()
+		Expect(status.Reason).To(ContainSubstring("is installed in namespace"))
+		for conditionType, expected := range map[string]metav1.ConditionStatus{
+			kedav1alpha1.ConditionAvailable:              metav1.ConditionTrue,
+			kedav1alpha1.ConditionProgressing:            metav1.ConditionFalse,
+			kedav1alpha1.ConditionDegraded:               metav1.ConditionFalse,
+			kedav1alpha1.ConditionOperatorReady:          metav1.ConditionTrue,
+			kedav1alpha1.ConditionAdmissionWebhooksReady: metav1.ConditionTrue,
+			kedav1alpha1.ConditionMetricsServerReady:     metav1.ConditionFalse,
+		} {
+			condition := status.GetCondition(conditionType)
+			Expect(condition).NotTo(BeNil(), "condition %s is not set", conditionType)
+			Expect(condition.Status).To(Equal(expected), "condition %s", conditionType)
+			Expect(condition.ObservedGeneration).To(Equal(generation), "condition %s", conditionType)
+		}
+		Expect(status.GetCondition(kedav1alpha1.ConditionAvailable).Message).To(Equal(status.Reason))
+		Expect(status.GetCondition(kedav1alpha1.ConditionOperatorReady).Reason).To(Equal(kedav1alpha1.ReasonRolloutComplete))
+		Expect(status.GetCondition(kedav1alpha1.ConditionMetricsServerReady).Reason).To(Equal(kedav1alpha1.ReasonDisabled))
+	})
+
+	It("Should report the error of a failed installation in the component condition", func() {
+		const claimName = "keda-missing-audit-log"
+		failing, err := changeAttribute(manifest, "auditLogOutputVolumeClaim", claimName, scheme, "status of a failed installation")
+		Expect(err).To(BeNil())
+		Expect(failing.Apply()).To(Succeed())
+
+		Eventually(func() kedav1alpha1.KedaControllerPhase {
+			status, _ := getStatus()
+			return status.Phase
+		}, timeout, interval).Should(Equal(kedav1alpha1.PhaseFailed))
+
+		status, generation := getStatus()
+		Expect(status.ObservedGeneration).To(Equal(generation))
+		Expect(status.Reason).To(Equal("Not able to install KEDA Metrics Server"))
+
+		degraded := status.GetCondition(kedav1alpha1.ConditionDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(kedav1alpha1.ReasonInstallFailed))
+		Expect(degraded.Message).To(Equal(status.Reason))
+		Expect(status.GetCondition(kedav1alpha1.ConditionAvailable).Status).To(Equal(metav1.ConditionFalse))
+
+		metricsServer := status.GetCondition(kedav1alpha1.ConditionMetricsServerReady)
+		Expect(metricsServer).NotTo(BeNil())
+		Expect(metricsServer.Status).To(Equal(metav1.ConditionFalse))
+		Expect(metricsServer.Reason).To(Equal(kedav1alpha1.ReasonInstallFailed))
+		// the condition carries the underlying error, not only the summary
+		Expect(metricsServer.Message).To(HavePrefix(status.Reason + ": "))
+		Expect(metricsServer.Message).To(ContainSubstring(claimName))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	// dashboardLabel marks the dashboard ConfigMaps, they may live outside of the namespace of the KedaController
+	// where no owner reference can be set, so they are found through this label when removed
+	dashboardLabel      = "olm-operator.keda.sh/dashboard"
+	dashboardLabelValue = "keda"
+
+	consoleDashboardLabel        = "console.openshift.io/dashboard"
+	openshiftDashboardsNamespace = "openshift-config-managed"
+)
+
+var configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+// dashboardsNamespace returns the namespace the dashboard ConfigMaps are installed to
+func dashboardsNamespace(instance *kedav1alpha1.KedaController, onOpenshift bool) string {
+	if namespace := instance.Spec.Monitoring.Dashboards.Namespace; namespace != "" {
+		return namespace
+	}
+	if onOpenshift {
+		return openshiftDashboardsNamespace
+	}
+	return instance.Namespace
+}
+
+// installDashboards installs the dashboard ConfigMaps when they are enabled and removes them otherwise,
+// as well as the ones left behind in another namespace
+func (r *KedaControllerReconciler) installDashboards(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	dashboards := instance.Spec.Monitoring.Dashboards
+	if !instance.Spec.Monitoring.IsEnabled() || !dashboards.Enabled {
+		return r.removeDashboards(ctx, logger, "")
+	}
+
+	onOpenshift := util.RunningOnOpenshift(ctx, logger, r.Client)
+	namespace := dashboardsNamespace(instance, onOpenshift)
+
+	labels := map[string]string{}
+	for key, value := range dashboards.AdditionalLabels {
+		labels[key] = value
+	}
+	labels[dashboardLabel] = dashboardLabelValue
+	if onOpenshift {
+		labels[consoleDashboardLabel] = "true"
+	}
+
+	transforms := []mf.Transformer{
+		transform.ReplaceAllNamespaces(namespace),
+		transform.AddLabels(labels),
+	}
+	// owner references can't point to another namespace, those ConfigMaps are removed by the finalizer
+	if namespace == instance.Namespace {
+		transforms = append(transforms, transform.InjectOwner(instance))
+	}
+
+	manifest, err := r.resourcesDashboards.Transform(transforms...)
+	if err != nil {
+		logger.Error(err, "Unable to transform dashboard manifests")
+		return err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install dashboards")
+		return err
+	}
+	return r.removeDashboards(ctx, logger, namespace)
+}
+
+// removeDashboards removes the dashboard ConfigMaps of all namespaces except keepNamespace
+func (r *KedaControllerReconciler) removeDashboards(ctx context.Context, logger logr.Logger, keepNamespace string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(configMapGVK)
+	if err := r.Client.List(ctx, list, client.MatchingLabels{dashboardLabel: dashboardLabelValue}); err != nil {
+		logger.Error(err, "Unable to list dashboards")
+		return err
+	}
+
+	for i := range list.Items {
+		dashboard := &list.Items[i]
+		if dashboard.GetNamespace() == keepNamespace {
+			continue
+		}
+		if err := r.Client.Delete(ctx, dashboard); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Unable to remove dashboard", "ConfigMap.Namespace", dashboard.GetNamespace(), "ConfigMap.Name", dashboard.GetName())
+			return err
+		}
+	}
+	return nil
+}
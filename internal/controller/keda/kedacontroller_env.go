@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"slices"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	// checksum of the ConfigMaps and Secrets referenced by env and envFrom, a change rolls out the Deployment
+	envChecksumAnnotation = "olm-operator.keda.sh/env-checksum"
+)
+
+// envTransforms returns the transformations merging env and envFrom of a KEDA component into its container
+// and stamping the checksum of the referenced ConfigMaps and Secrets into the pod template
+func (r *KedaControllerReconciler) envTransforms(ctx context.Context, instance *kedav1alpha1.KedaController, spec kedav1alpha1.GenericDeploymentSpec,
+	replaceEnv func([]corev1.EnvVar, []corev1.EnvFromSource, *runtime.Scheme) mf.Transformer) ([]mf.Transformer, error) {
+	if len(spec.Env) == 0 && len(spec.EnvFrom) == 0 {
+		return nil, nil
+	}
+
+	transforms := []mf.Transformer{replaceEnv(spec.Env, spec.EnvFrom, r.Scheme)}
+
+	checksum, err := r.envChecksum(ctx, instance.Namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != "" {
+		transforms = append(transforms, transform.AddPodAnnotations(map[string]string{envChecksumAnnotation: checksum}, r.Scheme))
+	}
+	return transforms, nil
+}
+
+// envChecksum returns a checksum of the data of the ConfigMaps and Secrets referenced by env and envFrom
+// of a KEDA component, or an empty string when there are no references
+func (r *KedaControllerReconciler) envChecksum(ctx context.Context, namespace string, spec kedav1alpha1.GenericDeploymentSpec) (string, error) {
+	configMaps, secrets := util.EnvReferences(spec.Env, spec.EnvFrom)
+	if len(configMaps) == 0 && len(secrets) == 0 {
+		return "", nil
+	}
+	return r.referencesChecksum(ctx, namespace, configMaps, secrets)
+}
+
+// referencesChecksum returns a checksum of the data of the ConfigMaps and Secrets. Missing objects are part of
+// the checksum too, so that creating them later rolls out the Deployment as well
+func (r *KedaControllerReconciler) referencesChecksum(ctx context.Context, namespace string, configMaps, secrets []string) (string, error) {
+	sums := make(map[string]string, len(configMaps)+len(secrets))
+	for _, name := range configMaps {
+		cm := &corev1.ConfigMap{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", err
+			}
+			sums["configmap/"+name] = ""
+			continue
+		}
+		sums["configmap/"+name] = util.CalculateConfigMapDataCheckSum(cm.Data)
+	}
+	for _, name := range secrets {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", err
+			}
+			sums["secret/"+name] = ""
+			continue
+		}
+		sums["secret/"+name] = util.CalculateSecretedDataCheckSum(secret.Data)
+	}
+
+	return util.CalculateConfigMapDataCheckSum(sums), nil
+}
+
+// kedaControllerForReference returns a handler.MapFunc enqueuing the KedaController when the ConfigMap
+// or Secret passed to it is referenced by env, envFrom, the trusted CAs of any KEDA component or the
+// user-provided certificates
+func (r *KedaControllerReconciler) kedaControllerForReference(isSecret bool) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetNamespace() != r.resourceNamespace {
+			return nil
+		}
+
+		key := types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}
+		instance := &kedav1alpha1.KedaController{}
+		if err := r.Client.Get(ctx, key, instance); err != nil {
+			return nil
+		}
+
+		if !isSecret && slices.Contains(instance.Spec.Operator.CAConfigMaps, obj.GetName()) {
+			return []reconcile.Request{{NamespacedName: key}}
+		}
+		if instance.Spec.Certificates.Provider == kedav1alpha1.CertificatesProviderUserProvided {
+			configMaps, secrets := userProvidedReferences(instance.Spec.Certificates.UserProvided)
+			if (isSecret && slices.Contains(secrets, obj.GetName())) || (!isSecret && slices.Contains(configMaps, obj.GetName())) {
+				return []reconcile.Request{{NamespacedName: key}}
+			}
+		}
+		for _, spec := range []struct {
+			deployment kedav1alpha1.GenericDeploymentSpec
+			trustedCA  kedav1alpha1.TrustedCASpec
+		}{
+			{instance.Spec.Operator.GenericDeploymentSpec, instance.Spec.Operator.TrustedCASpec},
+			{instance.Spec.MetricsServer.GenericDeploymentSpec, instance.Spec.MetricsServer.TrustedCASpec},
+			{instance.Spec.AdmissionWebhooks.GenericDeploymentSpec, instance.Spec.AdmissionWebhooks.TrustedCASpec},
+		} {
+			configMaps, secrets := util.EnvReferences(spec.deployment.Env, spec.deployment.EnvFrom)
+			caConfigMaps, caSecrets := util.CAReferences(spec.trustedCA.CASources)
+			referenced := append(configMaps, caConfigMaps...)
+			if isSecret {
+				referenced = append(secrets, caSecrets...)
+			}
+			if slices.Contains(referenced, obj.GetName()) {
+				return []reconcile.Request{{NamespacedName: key}}
+			}
+		}
+		return nil
+	}
+}
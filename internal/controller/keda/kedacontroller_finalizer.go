@@ -4,9 +4,11 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
 )
 
 const (
@@ -14,20 +16,61 @@ const (
 )
 
 // finalizeKedaController is deleting resources for the respective KedaController
-func (r *KedaControllerReconciler) finalizeKedaController(logger logr.Logger) error {
-	if err := r.resourcesGeneral.Delete(); err != nil {
+func (r *KedaControllerReconciler) finalizeKedaController(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	// the stored manifests are not rendered, place them in the namespaces they were installed to
+	namespaceTransforms := []mf.Transformer{
+		transform.ReplaceAllNamespaces(instance.Namespace),
+		transform.ReplaceNamespace(roleBindingName, roleBindingNamespace, r.Scheme, logger),
+	}
+
+	general, err := r.resourcesGeneral.Transform(namespaceTransforms...)
+	if err != nil {
+		return err
+	}
+	if err := general.Delete(); err != nil {
 		logger.Info("error finalized KedaController general", "error", err)
 		return err
 	}
-	if err := r.resourcesController.Delete(); err != nil {
+
+	controller, err := r.resourcesController.Transform(namespaceTransforms...)
+	if err != nil {
+		return err
+	}
+	if err := controller.Delete(); err != nil {
 		logger.Info("error finalized KedaController controller", "error", err)
 		return err
 	}
-	if err := r.resourcesMetrics.Delete(); err != nil {
+
+	metrics, err := r.resourcesMetrics.Transform(namespaceTransforms...)
+	if err != nil {
+		return err
+	}
+	if err := metrics.Delete(); err != nil {
 		logger.Info("error finalized KedaController metrics", "error", err)
 		return err
 	}
 
+	// dashboards outside of the namespace of the KedaController are not garbage collected
+	if err := r.removeDashboards(ctx, logger, ""); err != nil {
+		logger.Info("error finalized KedaController dashboards", "error", err)
+		return err
+	}
+
+	if err := r.removeWatchedNamespacesRBAC(ctx, logger, nil); err != nil {
+		logger.Info("error finalized KedaController RBAC of watched namespaces", "error", err)
+		return err
+	}
+
+	if err := r.removeClusterTriggerAuthentications(ctx, logger, nil); err != nil {
+		logger.Info("error finalized KedaController ClusterTriggerAuthentications", "error", err)
+		return err
+	}
+
+	if err := r.removeOpenshiftMonitoring(ctx, logger, ""); err != nil {
+		logger.Info("error finalized KedaController OpenShift monitoring authentication", "error", err)
+		return err
+	}
+
 	// DO NOT manage deletion of namespace at the moment (as it was created manually)
 	// if err := r.removeNamespace(installationNamespace); err != nil {
 	// 	logger.Info("error finalized KedaController namespace", "error", err)
@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+)
+
+// highAvailabilityTransforms returns the transformations setting the number of replicas of a KEDA component,
+// when it runs more than one replica its pods are spread across nodes and zones unless the user configured
+// affinity or topologySpreadConstraints
+func (r *KedaControllerReconciler) highAvailabilityTransforms(spec kedav1alpha1.GenericDeploymentSpec) []mf.Transformer {
+	var transforms []mf.Transformer
+
+	if spec.Replicas != nil {
+		transforms = append(transforms, transform.ReplaceReplicas(*spec.Replicas, r.Scheme))
+	}
+
+	if len(spec.TopologySpreadConstraints) > 0 {
+		transforms = append(transforms, transform.ReplaceTopologySpreadConstraints(spec.TopologySpreadConstraints, r.Scheme))
+	}
+
+	if spec.Replicas != nil && *spec.Replicas > 1 {
+		if spec.Affinity == nil {
+			transforms = append(transforms, transform.AddDefaultPodAntiAffinity(r.Scheme))
+		}
+		if len(spec.TopologySpreadConstraints) == 0 {
+			transforms = append(transforms, transform.AddDefaultTopologySpreadConstraints(r.Scheme))
+		}
+	}
+
+	return transforms
+}
+
+// ensurePodDisruptionBudget creates or updates the PodDisruptionBudget of a KEDA component running more than
+// one replica and removes it once the component is scaled back to a single replica, where it would only block
+// node drains
+func (r *KedaControllerReconciler) ensurePodDisruptionBudget(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	name string, appLabel string, spec kedav1alpha1.GenericDeploymentSpec) error {
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, pdb)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to get PodDisruptionBudget from cluster", "PodDisruptionBudget.Name", name)
+		return err
+	}
+	found := err == nil
+
+	if spec.Replicas == nil || *spec.Replicas <= 1 {
+		if found {
+			logger.Info("Removing PodDisruptionBudget of a single replica Deployment", "PodDisruptionBudget.Name", name)
+			if err := r.Client.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete PodDisruptionBudget", "PodDisruptionBudget.Name", name)
+				return err
+			}
+		}
+		return nil
+	}
+
+	desired := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": appLabel}},
+	}
+	if spec.PodDisruptionBudget != nil && (spec.PodDisruptionBudget.MinAvailable != nil || spec.PodDisruptionBudget.MaxUnavailable != nil) {
+		if spec.PodDisruptionBudget.MinAvailable != nil && spec.PodDisruptionBudget.MaxUnavailable != nil {
+			return fmt.Errorf("only one of minAvailable and maxUnavailable can be set in podDisruptionBudget of %s", name)
+		}
+		desired.MinAvailable = spec.PodDisruptionBudget.MinAvailable
+		desired.MaxUnavailable = spec.PodDisruptionBudget.MaxUnavailable
+	} else {
+		maxUnavailable := intstr.FromInt32(1)
+		desired.MaxUnavailable = &maxUnavailable
+	}
+
+	if !found {
+		pdb.Name = name
+		pdb.Namespace = instance.Namespace
+		pdb.Spec = desired
+
+		if err := controllerutil.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set Controller Reference for PodDisruptionBudget")
+			return err
+		}
+
+		if err := r.Client.Create(ctx, pdb); err != nil {
+			logger.Error(err, "Failed to create new PodDisruptionBudget in cluster", "PodDisruptionBudget.Namespace", instance.Namespace, "PodDisruptionBudget.Name", name)
+			return err
+		}
+		return nil
+	}
+
+	pdbUpdate := false
+	if !reflect.DeepEqual(pdb.Spec.Selector, desired.Selector) ||
+		!reflect.DeepEqual(pdb.Spec.MinAvailable, desired.MinAvailable) ||
+		!reflect.DeepEqual(pdb.Spec.MaxUnavailable, desired.MaxUnavailable) {
+		pdb.Spec.Selector = desired.Selector
+		pdb.Spec.MinAvailable = desired.MinAvailable
+		pdb.Spec.MaxUnavailable = desired.MaxUnavailable
+		pdbUpdate = true
+	}
+
+	if err := controllerutil.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+		if !goerrors.Is(err, &controllerutil.AlreadyOwnedError{}) {
+			logger.Error(err, "Failed to check Controller Reference for PodDisruptionBudget")
+			return err
+		}
+	} else {
+		pdbUpdate = true
+	}
+
+	if pdbUpdate {
+		if err := r.Client.Update(ctx, pdb); err != nil {
+			logger.Error(err, "Failed to update PodDisruptionBudget in cluster", "PodDisruptionBudget.Namespace", instance.Namespace, "PodDisruptionBudget.Name", name)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	externalMetricsAPIServiceName = "v1beta1.external.metrics.k8s.io"
+
+	// how often to check the workloads again while they are rolling out
+	rolloutRequeueInterval = 10 * time.Second
+)
+
+// kedaComponent ties a component condition to the Deployment that runs the component
+type kedaComponent struct {
+	conditionType  string
+	deploymentName string
+	// enabled reports whether the component is installed, nil for components which are always installed
+	enabled func(spec *kedav1alpha1.KedaControllerSpec) bool
+}
+
+var kedaComponents = []kedaComponent{
+	{conditionType: kedav1alpha1.ConditionOperatorReady, deploymentName: "keda-operator"},
+	{
+		conditionType:  kedav1alpha1.ConditionMetricsServerReady,
+		deploymentName: "keda-metrics-apiserver",
+		enabled:        func(spec *kedav1alpha1.KedaControllerSpec) bool { return spec.MetricsServer.IsEnabled() },
+	},
+	{
+		conditionType:  kedav1alpha1.ConditionAdmissionWebhooksReady,
+		deploymentName: "keda-admission",
+		enabled:        func(spec *kedav1alpha1.KedaControllerSpec) bool { return spec.AdmissionWebhooks.IsEnabled() },
+	},
+}
+
+// checkWorkloadHealth reads the rollout progress of the Deployments of the enabled KEDA components and the availability of the
+// external metrics APIService and reports them in the component conditions. It returns the overall
+// rollout state and a message summarizing the components that are not ready.
+func (r *KedaControllerReconciler) checkWorkloadHealth(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	status *kedav1alpha1.KedaControllerStatus) (util.RolloutState, string, error) {
+	state := util.RolloutComplete
+	var pending []string
+
+	for _, component := range kedaComponents {
+		// disabled components are reported by the reconcile loop
+		if component.enabled != nil && !component.enabled(&instance.Spec) {
+			continue
+		}
+
+		var rolloutState util.RolloutState
+		var msg string
+
+		deploy := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: component.deploymentName, Namespace: instance.Namespace}, deploy); err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Error(err, "Unable to get Deployment", "Deployment.Name", component.deploymentName)
+				return state, "", err
+			}
+			// the Deployment has just been applied and is not in the cache yet
+			rolloutState, msg = util.RolloutProgressing, "waiting for Deployment "+component.deploymentName+" to be created"
+		} else {
+			rolloutState, msg = util.GetDeploymentRolloutState(deploy)
+		}
+
+		if rolloutState == util.RolloutComplete && component.conditionType == kedav1alpha1.ConditionMetricsServerReady {
+			rolloutState, msg = r.checkExternalMetricsAPIService(ctx, logger)
+		}
+
+		switch rolloutState {
+		case util.RolloutComplete:
+			status.MarkComponentReady(component.conditionType, kedav1alpha1.ReasonRolloutComplete, msg)
+		case util.RolloutFailed:
+			status.MarkComponentFailed(component.conditionType, kedav1alpha1.ReasonRolloutFailed, msg)
+			state = util.RolloutFailed
+			pending = append(pending, msg)
+		default:
+			status.MarkComponentFailed(component.conditionType, kedav1alpha1.ReasonRolloutInProgress, msg)
+			if state != util.RolloutFailed {
+				state = util.RolloutProgressing
+			}
+			pending = append(pending, msg)
+		}
+	}
+
+	return state, strings.Join(pending, "; "), nil
+}
+
+// checkExternalMetricsAPIService checks that the Kubernetes API server is able to reach KEDA Metrics Server
+func (r *KedaControllerReconciler) checkExternalMetricsAPIService(ctx context.Context, logger logr.Logger) (util.RolloutState, string) {
+	apiService := &apiregistrationv1.APIService{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: externalMetricsAPIServiceName}, apiService); err != nil {
+		logger.Info("Unable to get APIService", "APIService.Name", externalMetricsAPIServiceName, "error", err)
+		return util.RolloutProgressing, "waiting for APIService " + externalMetricsAPIServiceName + ": " + err.Error()
+	}
+	if available, msg := util.IsAPIServiceAvailable(apiService); !available {
+		return util.RolloutProgressing, msg
+	}
+	return util.RolloutComplete, "KEDA Metrics Server is available through APIService " + externalMetricsAPIServiceName
+}
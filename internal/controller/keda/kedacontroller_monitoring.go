@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"encoding/json"
+	"slices"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// monitorComponent ties a ServiceMonitor or PodMonitor to the toggles turning it off
+type monitorComponent struct {
+	name    string
+	enabled func(spec *kedav1alpha1.KedaControllerSpec) bool
+}
+
+var monitorComponents = []monitorComponent{
+	{
+		name: "keda-operator",
+		enabled: func(spec *kedav1alpha1.KedaControllerSpec) bool {
+			return spec.Monitoring.Components.IsOperatorEnabled()
+		},
+	},
+	{
+		name: "keda-metrics-apiserver",
+		enabled: func(spec *kedav1alpha1.KedaControllerSpec) bool {
+			return spec.MetricsServer.IsEnabled() && spec.Monitoring.Components.IsMetricsServerEnabled()
+		},
+	},
+	{
+		name: "keda-admission-webhooks",
+		enabled: func(spec *kedav1alpha1.KedaControllerSpec) bool {
+			return spec.AdmissionWebhooks.IsEnabled() && spec.Monitoring.Components.IsAdmissionWebhooksEnabled()
+		},
+	},
+	{
+		name: "keda-olm-operator",
+		enabled: func(spec *kedav1alpha1.KedaControllerSpec) bool {
+			return spec.Monitoring.Components.IsOLMOperatorEnabled()
+		},
+	},
+}
+
+// disabledMonitors matches the ServiceMonitors and the PodMonitor which are not installed, either because
+// monitoring or the monitored component is disabled in the spec
+func disabledMonitors(instance *kedav1alpha1.KedaController) mf.Predicate {
+	var names []string
+	for _, component := range monitorComponents {
+		if !instance.Spec.Monitoring.IsEnabled() || !component.enabled(&instance.Spec) {
+			names = append(names, component.name)
+		}
+	}
+	return func(u *unstructured.Unstructured) bool {
+		return slices.Contains(names, u.GetName())
+	}
+}
+
+// monitoringModeWithoutCRDs returns the monitoring mode when the Prometheus Operator CRDs are not present
+func monitoringModeWithoutCRDs(instance *kedav1alpha1.KedaController) kedav1alpha1.MonitoringMode {
+	if !instance.Spec.Monitoring.IsEnabled() {
+		return kedav1alpha1.MonitoringModeDisabled
+	}
+	return kedav1alpha1.MonitoringModeUnavailable
+}
+
+// relabelingsToUnstructured converts the relabelings to the representation used in the monitor manifests,
+// going through JSON keeps the numbers as int64, which the unstructured objects expect
+func relabelingsToUnstructured(relabelings []kedav1alpha1.RelabelConfig) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	return result, toUnstructured(relabelings, &result)
+}
+
+// authorizationToUnstructured converts the TLS configuration and the bearer token Secret of the scrapes to the
+// representation used in the monitor manifests, unset values are returned as nil
+func authorizationToUnstructured(spec kedav1alpha1.MonitoringSpec) (tlsConfig, bearerTokenSecret map[string]interface{}, err error) {
+	if spec.TLSConfig != nil {
+		if err := toUnstructured(spec.TLSConfig, &tlsConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+	if spec.BearerTokenSecret != nil {
+		if err := toUnstructured(spec.BearerTokenSecret, &bearerTokenSecret); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tlsConfig, bearerTokenSecret, nil
+}
+
+func toUnstructured(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return utiljson.Unmarshal(data, out)
+}
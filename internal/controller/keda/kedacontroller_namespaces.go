@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// watchedNamespaces returns the sorted namespaces watched by KEDA, nil when it watches all namespaces
+func (r *KedaControllerReconciler) watchedNamespaces(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) ([]string, error) {
+	namespaces := slices.Clone(instance.Spec.WatchNamespaces)
+	if instance.Spec.WatchNamespace != "" {
+		namespaces = append(namespaces, instance.Spec.WatchNamespace)
+	}
+
+	if instance.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(instance.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		list := &corev1.NamespaceList{}
+		if err := r.Client.List(ctx, list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			logger.Error(err, "Unable to list namespaces matching namespaceSelector")
+			return nil, err
+		}
+		for _, namespace := range list.Items {
+			namespaces = append(namespaces, namespace.Name)
+		}
+
+		// an empty WATCH_NAMESPACE means all namespaces, which is the opposite of what the selector asks for
+		if len(namespaces) == 0 {
+			logger.Info("No namespace matches namespaceSelector, KEDA watches only its own namespace")
+			namespaces = []string{instance.Namespace}
+		}
+	}
+
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces), nil
+}
+
+// watchNamespace returns the value of WATCH_NAMESPACE, the comma-separated namespaces watched by KEDA
+func (r *KedaControllerReconciler) watchNamespace(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) (string, error) {
+	namespaces, err := r.watchedNamespaces(ctx, logger, instance)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(namespaces, ","), nil
+}
+
+// kedaControllerForNamespace returns a handler.MapFunc enqueuing the KedaController when a namespace is created,
+// deleted or relabeled while the watched namespaces are chosen by namespaceSelector
+func (r *KedaControllerReconciler) kedaControllerForNamespace() handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		key := types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}
+		instance := &kedav1alpha1.KedaController{}
+		if err := r.Client.Get(ctx, key, instance); err != nil {
+			return nil
+		}
+		if instance.Spec.NamespaceSelector == nil {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: key}}
+	}
+}
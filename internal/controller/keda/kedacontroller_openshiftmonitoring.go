@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	thanosQuerierNamespace   = "openshift-monitoring"
+	thanosQuerierServiceName = "thanos-querier"
+	thanosQuerierAddress     = "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091"
+
+	// the ServiceAccount allowed to view the cluster monitoring, KEDA Operator requests its bound tokens to query Thanos Querier
+	thanosQuerierServiceAccount         = "keda-thanos-querier"
+	thanosQuerierClusterRoleBindingName = "keda-thanos-querier-cluster-monitoring-view"
+	thanosQuerierTokenRBACName          = "keda-operator-thanos-querier-token"
+	clusterMonitoringViewClusterRole    = "cluster-monitoring-view"
+	defaultThanosQuerierTriggerAuthName = "keda-thanos-querier"
+
+	// key of the service CA bundle injected into caBundleConfigMapName
+	serviceCABundleKey = "service-ca.crt"
+
+	openshiftMonitoringLabel      = "olm-operator.keda.sh/openshift-monitoring"
+	openshiftMonitoringLabelValue = "keda"
+)
+
+// the kinds of the resources created for the OpenShift monitoring, the ClusterRoleBinding and the ClusterTriggerAuthentication
+// are cluster-scoped, so all of them are found through the label when removed
+var openshiftMonitoringGVKs = []schema.GroupVersionKind{
+	clusterTriggerAuthenticationGVK,
+	rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+	rbacv1.SchemeGroupVersion.WithKind("RoleBinding"),
+	rbacv1.SchemeGroupVersion.WithKind("Role"),
+	corev1.SchemeGroupVersion.WithKind("ServiceAccount"),
+}
+
+// thanosQuerierTriggerAuthName returns the name of the ClusterTriggerAuthentication for Thanos Querier
+func thanosQuerierTriggerAuthName(instance *kedav1alpha1.KedaController) string {
+	if name := instance.Spec.OpenShiftMonitoring.ClusterTriggerAuthentication; name != "" {
+		return name
+	}
+	return defaultThanosQuerierTriggerAuthName
+}
+
+// installOpenshiftMonitoring installs the ServiceAccount allowed to view the cluster monitoring, the RBAC allowing KEDA Operator
+// to request its tokens and the ClusterTriggerAuthentication for Thanos Querier, and reports their readiness in the status
+func (r *KedaControllerReconciler) installOpenshiftMonitoring(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	status *kedav1alpha1.KedaControllerStatus) error {
+	if !instance.Spec.OpenShiftMonitoring.Enabled {
+		status.MarkComponentDisabled(kedav1alpha1.ConditionOpenShiftMonitoringReady, "OpenShift monitoring authentication is disabled")
+		return r.removeOpenshiftMonitoring(ctx, logger, "")
+	}
+	logger.Info("Reconciling OpenShift monitoring authentication")
+
+	// the cache of the manager covers only the install namespace, unstructured objects are read from the API server
+	thanosQuerier := &unstructured.Unstructured{}
+	thanosQuerier.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: thanosQuerierServiceName, Namespace: thanosQuerierNamespace}, thanosQuerier); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		status.MarkComponentFailed(kedav1alpha1.ConditionOpenShiftMonitoringReady, "ThanosQuerierNotFound",
+			fmt.Sprintf("Service %s/%s is not present in the cluster", thanosQuerierNamespace, thanosQuerierServiceName))
+		return r.removeOpenshiftMonitoring(ctx, logger, "")
+	}
+	hasCRD, err := r.hasClusterTriggerAuthenticationCRD(ctx, logger)
+	if err != nil {
+		return err
+	}
+	if !hasCRD {
+		status.MarkComponentFailed(kedav1alpha1.ConditionOpenShiftMonitoringReady, "ClusterTriggerAuthenticationCRDNotFound",
+			"ClusterTriggerAuthentication CRD is not present in the cluster")
+		return nil
+	}
+
+	if err := r.ensureOpenshiftCABundleConfigMap(ctx, logger, instance); err != nil {
+		return err
+	}
+	name := thanosQuerierTriggerAuthName(instance)
+	manifest, err := openshiftMonitoringManifest(instance, name)
+	if err != nil {
+		return err
+	}
+	if manifest, err = manifest.Transform(transform.InjectOwner(instance)); err != nil {
+		return err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install OpenShift monitoring authentication")
+		return err
+	}
+	if err := r.removeOpenshiftMonitoring(ctx, logger, name); err != nil {
+		return err
+	}
+
+	// the service CA operator injects the bundle asynchronously, the ConfigMap watch reconciles again once it is there
+	caBundle := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: caBundleConfigMapName, Namespace: instance.Namespace}, caBundle); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if caBundle.Data[serviceCABundleKey] == "" {
+		status.MarkComponentFailed(kedav1alpha1.ConditionOpenShiftMonitoringReady, "CABundleNotInjected",
+			fmt.Sprintf("Waiting for the service CA bundle to be injected into ConfigMap %s", caBundleConfigMapName))
+		return nil
+	}
+
+	status.MarkComponentReady(kedav1alpha1.ConditionOpenShiftMonitoringReady, kedav1alpha1.ReasonInstallSucceeded,
+		fmt.Sprintf("ClusterTriggerAuthentication %s authenticates Prometheus scalers against %s", name, thanosQuerierAddress))
+	return nil
+}
+
+// openshiftMonitoringManifest renders the resources authenticating the Prometheus scalers against Thanos Querier
+func openshiftMonitoringManifest(instance *kedav1alpha1.KedaController, triggerAuthName string) (mf.Manifest, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/part-of": "keda",
+		openshiftMonitoringLabel:    openshiftMonitoringLabelValue,
+	}
+	serviceAccountSubject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: thanosQuerierServiceAccount, Namespace: instance.Namespace}
+
+	objects := []runtime.Object{
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierServiceAccount, Namespace: instance.Namespace, Labels: labels},
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierClusterRoleBindingName, Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterMonitoringViewClusterRole},
+			Subjects:   []rbacv1.Subject{serviceAccountSubject},
+		},
+		// KEDA Operator requests the bound tokens of the ServiceAccount itself
+		&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierTokenRBACName, Namespace: instance.Namespace, Labels: labels},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"serviceaccounts/token"},
+				ResourceNames: []string{thanosQuerierServiceAccount},
+				Verbs:         []string{"create"},
+			}},
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: thanosQuerierTokenRBACName, Namespace: instance.Namespace, Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: thanosQuerierTokenRBACName},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: kedaOperatorServiceAccount, Namespace: instance.Namespace}},
+		},
+	}
+
+	var resources []unstructured.Unstructured
+	for _, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return mf.Manifest{}, err
+		}
+		resources = append(resources, unstructured.Unstructured{Object: content})
+	}
+
+	triggerAuthentication := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"boundServiceAccountToken": []interface{}{
+				map[string]interface{}{"parameter": "bearerToken", "serviceAccountName": thanosQuerierServiceAccount},
+			},
+			"configMapTargetRef": []interface{}{
+				map[string]interface{}{"parameter": "ca", "name": caBundleConfigMapName, "key": serviceCABundleKey},
+			},
+		},
+	}}
+	triggerAuthentication.SetGroupVersionKind(clusterTriggerAuthenticationGVK)
+	triggerAuthentication.SetName(triggerAuthName)
+	triggerAuthentication.SetLabels(labels)
+	resources = append(resources, triggerAuthentication)
+
+	return mf.ManifestFrom(mf.Slice(resources))
+}
+
+// removeOpenshiftMonitoring removes the resources created for the OpenShift monitoring, except for the ones still in use
+// when keepTriggerAuthName is set, i.e. only the ClusterTriggerAuthentications of another name are removed then
+func (r *KedaControllerReconciler) removeOpenshiftMonitoring(ctx context.Context, logger logr.Logger, keepTriggerAuthName string) error {
+	for _, gvk := range openshiftMonitoringGVKs {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := r.Client.List(ctx, list, client.MatchingLabels{openshiftMonitoringLabel: openshiftMonitoringLabelValue}); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			logger.Error(err, "Unable to list OpenShift monitoring authentication resources", "kind", gvk.Kind)
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if keepTriggerAuthName != "" && (gvk != clusterTriggerAuthenticationGVK || obj.GetName() == keepTriggerAuthName) {
+				continue
+			}
+			if err := r.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Unable to remove OpenShift monitoring authentication resource", "kind", gvk.Kind, "name", obj.GetName())
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	// the ClusterTriggerAuthentications and the GCP credentials created for the pod identity carry this label,
+	// the former are cluster-scoped, so they are found through the label when pruned
+	podIdentityLabel      = "olm-operator.keda.sh/pod-identity"
+	podIdentityLabelValue = "keda"
+
+	// checksum of the GCP credential configuration, a change rolls out KEDA Operator
+	gcpCredentialsChecksumAnnotation = "olm-operator.keda.sh/gcp-credentials-checksum"
+)
+
+var clusterTriggerAuthenticationGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ClusterTriggerAuthentication"}
+
+// installPodIdentity installs the GCP credential configuration and the ClusterTriggerAuthentications of the pod identity,
+// removes the ones no longer requested and returns the transforms adding the pod identity to KEDA Operator
+func (r *KedaControllerReconciler) installPodIdentity(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) ([]mf.Transformer, error) {
+	identity, err := util.PodIdentityFor(instance.Spec.PodIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []unstructured.Unstructured
+	labels := map[string]string{
+		"app.kubernetes.io/part-of": "keda",
+		podIdentityLabel:            podIdentityLabelValue,
+	}
+	if identity.GCPCredentials != "" {
+		configMap := &unstructured.Unstructured{}
+		configMap.SetGroupVersionKind(configMapGVK)
+		configMap.SetName(util.GCPCredentialsConfigMapName)
+		configMap.SetNamespace(instance.Namespace)
+		configMap.SetLabels(labels)
+		if err := unstructured.SetNestedField(configMap.Object, identity.GCPCredentials, "data", util.GCPCredentialsKey); err != nil {
+			return nil, err
+		}
+		resources = append(resources, *configMap)
+	} else if err := r.removeGCPCredentials(ctx, logger, instance); err != nil {
+		return nil, err
+	}
+
+	providers := util.PodIdentityProviders(instance.Spec.PodIdentity)
+	hasCRD, err := r.hasClusterTriggerAuthenticationCRD(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	if hasCRD {
+		for name, provider := range providers {
+			triggerAuthentication := &unstructured.Unstructured{}
+			triggerAuthentication.SetGroupVersionKind(clusterTriggerAuthenticationGVK)
+			triggerAuthentication.SetName(name)
+			triggerAuthentication.SetLabels(labels)
+			if err := unstructured.SetNestedField(triggerAuthentication.Object, provider, "spec", "podIdentity", "provider"); err != nil {
+				return nil, err
+			}
+			resources = append(resources, *triggerAuthentication)
+		}
+	} else if len(providers) > 0 {
+		logger.Info("ClusterTriggerAuthentication CRD not found, skipping the ClusterTriggerAuthentications of the pod identity")
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		return nil, err
+	}
+	// owner references can't point from the cluster-scoped ClusterTriggerAuthentications, those are removed by the finalizer
+	if manifest, err = manifest.Transform(transform.InjectOwner(instance)); err != nil {
+		return nil, err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install the pod identity resources")
+		return nil, err
+	}
+	if hasCRD {
+		if err := r.removeClusterTriggerAuthentications(ctx, logger, providers); err != nil {
+			return nil, err
+		}
+	}
+
+	var transforms []mf.Transformer
+	if len(identity.Volumes) > 0 {
+		transforms = append(transforms,
+			transform.AddVolumes(identity.Volumes, r.Scheme, logger),
+			transform.AddKedaOperatorVolumeMounts(identity.VolumeMounts, r.Scheme, logger),
+		)
+	}
+	if len(identity.Env) > 0 {
+		transforms = append(transforms, transform.ReplaceKedaOperatorEnv(identity.Env, nil, r.Scheme))
+	}
+	if identity.GCPCredentials != "" {
+		checksum := util.CalculateConfigMapDataCheckSum(map[string]string{util.GCPCredentialsKey: identity.GCPCredentials})
+		transforms = append(transforms, transform.AddPodAnnotations(map[string]string{gcpCredentialsChecksumAnnotation: checksum}, r.Scheme))
+	}
+	return transforms, nil
+}
+
+// hasClusterTriggerAuthenticationCRD returns whether the ClusterTriggerAuthentication CRD of KEDA is served,
+// which is installed together with the operator but may be missing during upgrades
+func (r *KedaControllerReconciler) hasClusterTriggerAuthenticationCRD(ctx context.Context, logger logr.Logger) (bool, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(clusterTriggerAuthenticationGVK)
+	if err := r.Client.List(ctx, list, client.Limit(1)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		logger.Error(err, "Unable to list ClusterTriggerAuthentications")
+		return false, err
+	}
+	return true, nil
+}
+
+// removeGCPCredentials removes the GCP credential configuration created by the operator, a ConfigMap
+// of the same name without the pod identity label belongs to the user and is kept
+func (r *KedaControllerReconciler) removeGCPCredentials(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: util.GCPCredentialsConfigMapName, Namespace: instance.Namespace}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if configMap.Labels[podIdentityLabel] != podIdentityLabelValue {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, configMap); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Unable to remove the GCP credential configuration")
+		return err
+	}
+	return nil
+}
+
+// removeClusterTriggerAuthentications removes the ClusterTriggerAuthentications created for the pod identity
+// whose names are not in keep
+func (r *KedaControllerReconciler) removeClusterTriggerAuthentications(ctx context.Context, logger logr.Logger, keep map[string]string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(clusterTriggerAuthenticationGVK)
+	if err := r.Client.List(ctx, list, client.MatchingLabels{podIdentityLabel: podIdentityLabelValue}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		logger.Error(err, "Unable to list ClusterTriggerAuthentications of the pod identity")
+		return err
+	}
+
+	for i := range list.Items {
+		triggerAuthentication := &list.Items[i]
+		if _, found := keep[triggerAuthentication.GetName()]; found {
+			continue
+		}
+		if err := r.Client.Delete(ctx, triggerAuthentication); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Unable to remove ClusterTriggerAuthentication", "ClusterTriggerAuthentication.Name", triggerAuthentication.GetName())
+			return err
+		}
+	}
+	return nil
+}
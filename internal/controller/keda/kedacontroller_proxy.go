@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	clusterProxyName = "cluster"
+
+	// the cluster network operator injects the trusted CA bundle of the cluster, including the CA of the proxy,
+	// into the ConfigMaps carrying this label
+	trustedCABundleConfigMapName = "keda-trusted-ca-bundle"
+	injectTrustedCABundleLabel   = "config.openshift.io/inject-trusted-cabundle"
+)
+
+var proxyGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Proxy"}
+
+// proxyEnv returns the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the KEDA components,
+// the values set in the KedaController override the ones of the OpenShift cluster-wide Proxy
+func (r *KedaControllerReconciler) proxyEnv(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) ([]corev1.EnvVar, error) {
+	spec := instance.Spec.Proxy
+	values := []struct {
+		name  string
+		value string
+		field string
+	}{
+		{"HTTP_PROXY", spec.HTTPProxy, "httpProxy"},
+		{"HTTPS_PROXY", spec.HTTPSProxy, "httpsProxy"},
+		{"NO_PROXY", spec.NoProxy, "noProxy"},
+	}
+
+	proxy := &unstructured.Unstructured{}
+	proxy.SetGroupVersionKind(proxyGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterProxyName}, proxy); err != nil {
+		// the Proxy API is only served by OpenShift
+		if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			logger.Error(err, "Unable to get the cluster-wide Proxy")
+			return nil, err
+		}
+	} else {
+		for i := range values {
+			if values[i].value == "" {
+				values[i].value, _, _ = unstructured.NestedString(proxy.Object, "status", values[i].field)
+			}
+		}
+	}
+
+	var env []corev1.EnvVar
+	for _, v := range values {
+		if v.value != "" {
+			env = append(env, corev1.EnvVar{Name: v.name, Value: v.value})
+		}
+	}
+	return env, nil
+}
+
+// trustedCABundle returns the ConfigMap with the CA bundle KEDA Operator trusts to reach the proxy, empty for none.
+// On OpenShift the operator creates the ConfigMap the cluster network operator injects the trusted CA bundle into
+// while a proxy is used or a KEDA component trusts the CA bundle of the cluster, and removes it otherwise.
+func (r *KedaControllerReconciler) trustedCABundle(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	runningOnOpenshift bool, proxyEnv []corev1.EnvVar) (string, error) {
+	proxyCA := instance.Spec.Proxy.TrustedCAConfigMap
+	if proxyCA == trustedCABundleConfigMapName {
+		return proxyCA, nil
+	}
+	if proxyCA == "" && runningOnOpenshift && len(proxyEnv) > 0 {
+		proxyCA = trustedCABundleConfigMapName
+	}
+	if !runningOnOpenshift || (proxyCA != trustedCABundleConfigMapName && !clusterTrustedCABundleRequested(instance)) {
+		return proxyCA, r.removeTrustedCABundle(ctx, logger, instance)
+	}
+
+	// the data is owned by the cluster network operator, the operator applies only the metadata
+	configMap := &unstructured.Unstructured{}
+	configMap.SetGroupVersionKind(configMapGVK)
+	configMap.SetName(trustedCABundleConfigMapName)
+	configMap.SetNamespace(instance.Namespace)
+	configMap.SetLabels(map[string]string{
+		"app.kubernetes.io/part-of": "keda",
+		injectTrustedCABundleLabel:  "true",
+	})
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*configMap}))
+	if err != nil {
+		return "", err
+	}
+	if manifest, err = manifest.Transform(transform.InjectOwner(instance)); err != nil {
+		return "", err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install the trusted CA bundle ConfigMap")
+		return "", err
+	}
+	return proxyCA, nil
+}
+
+// removeTrustedCABundle removes the trusted CA bundle ConfigMap created by the operator, a ConfigMap
+// of the same name without the injection label belongs to the user and is kept
+func (r *KedaControllerReconciler) removeTrustedCABundle(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) error {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: trustedCABundleConfigMapName, Namespace: instance.Namespace}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if configMap.Labels[injectTrustedCABundleLabel] != "true" {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, configMap); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Unable to remove the trusted CA bundle ConfigMap")
+		return err
+	}
+	return nil
+}
+
+// kedaControllerForProxy enqueues the KedaController when the OpenShift cluster-wide Proxy changes
+func (r *KedaControllerReconciler) kedaControllerForProxy(_ context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != clusterProxyName {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}}}
+}
+
+// kedaControllerForConfigMap returns a handler.MapFunc enqueuing the KedaController when the trusted CA bundle,
+// the service CA bundle or a ConfigMap referenced by env, envFrom or the trusted CAs of any KEDA component changes
+func (r *KedaControllerReconciler) kedaControllerForConfigMap() handler.MapFunc {
+	forReference := r.kedaControllerForReference(false)
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetNamespace() != r.resourceNamespace {
+			return nil
+		}
+
+		key := types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}
+		if obj.GetName() == trustedCABundleConfigMapName || obj.GetName() == caBundleConfigMapName {
+			return []reconcile.Request{{NamespacedName: key}}
+		}
+		instance := &kedav1alpha1.KedaController{}
+		if err := r.Client.Get(ctx, key, instance); err == nil && obj.GetName() == instance.Spec.Proxy.TrustedCAConfigMap {
+			return []reconcile.Request{{NamespacedName: key}}
+		}
+		return forReference(ctx, obj)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const (
+	kedaOperatorClusterRoleName = "keda-operator"
+	kedaOperatorServiceAccount  = "keda-operator"
+
+	// the Roles and RoleBindings granting KEDA access to a watched namespace carry this name and label,
+	// they live outside of the namespace of the KedaController, so they are found through the label when pruned
+	watchedNamespaceRBACName       = "keda-operator-watched-namespace"
+	watchedNamespaceRBACLabel      = "olm-operator.keda.sh/watched-namespace-rbac"
+	watchedNamespaceRBACLabelValue = "keda"
+)
+
+// operatorPolicyRules splits the rules of the keda-operator ClusterRole into the ones kept in the ClusterRole
+// and the ones granted by a Role in each watched namespace
+func (r *KedaControllerReconciler) operatorPolicyRules() (clusterRules, namespacedRules []rbacv1.PolicyRule, err error) {
+	for _, u := range r.resourcesController.Resources() {
+		if u.GetKind() != "ClusterRole" || u.GetName() != kedaOperatorClusterRoleName {
+			continue
+		}
+		clusterRole := &rbacv1.ClusterRole{}
+		if err := r.Scheme.Convert(&u, clusterRole, nil); err != nil {
+			return nil, nil, err
+		}
+		clusterRules, namespacedRules = util.SplitPolicyRules(clusterRole.Rules)
+		return clusterRules, namespacedRules, nil
+	}
+	return nil, nil, fmt.Errorf("ClusterRole %s not found in the KEDA manifests", kedaOperatorClusterRoleName)
+}
+
+// rbacNamespaces returns the namespaces which get a Role with the namespaced rules, i.e. the watched namespaces
+// and the namespace of KEDA itself, which needs the same access there. It is nil while KEDA watches all namespaces.
+func rbacNamespaces(instance *kedav1alpha1.KedaController, watchedNamespaces []string) []string {
+	if len(watchedNamespaces) == 0 {
+		return nil
+	}
+	namespaces := append(slices.Clone(watchedNamespaces), instance.Namespace)
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+// watchedNamespacesRBACTransforms returns the transforms limiting the keda-operator ClusterRole to the cluster-scoped rules
+// and installs the Roles granting the namespaced rules in each watched namespace and in the namespace of KEDA. Nothing changes
+// while KEDA watches all namespaces. The Roles are installed before the ClusterRole is limited, so that KEDA doesn't lose
+// access in between.
+func (r *KedaControllerReconciler) watchedNamespacesRBACTransforms(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	namespaces []string) ([]mf.Transformer, error) {
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	clusterRules, namespacedRules, err := r.operatorPolicyRules()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.installWatchedNamespacesRBAC(ctx, logger, instance, rbacNamespaces(instance, namespaces), namespacedRules); err != nil {
+		return nil, err
+	}
+	return []mf.Transformer{transform.ReplaceClusterRoleRules(kedaOperatorClusterRoleName, clusterRules, r.Scheme)}, nil
+}
+
+// installWatchedNamespacesRBAC installs a Role with the namespaced rules and its RoleBinding in each watched namespace
+func (r *KedaControllerReconciler) installWatchedNamespacesRBAC(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	namespaces []string, rules []rbacv1.PolicyRule) error {
+	var resources []unstructured.Unstructured
+	for _, namespace := range namespaces {
+		objectMeta := metav1.ObjectMeta{
+			Name:      watchedNamespaceRBACName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":    kedaOperatorClusterRoleName,
+				"app.kubernetes.io/part-of": "keda-operator",
+				watchedNamespaceRBACLabel:   watchedNamespaceRBACLabelValue,
+			},
+		}
+		role := &rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: objectMeta,
+			Rules:      rules,
+		}
+		roleBinding := &rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: objectMeta,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: watchedNamespaceRBACName},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: kedaOperatorServiceAccount, Namespace: instance.Namespace}},
+		}
+		for _, obj := range []runtime.Object{role, roleBinding} {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return err
+			}
+			resources = append(resources, unstructured.Unstructured{Object: content})
+		}
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice(resources))
+	if err != nil {
+		return err
+	}
+	manifest, err = manifest.Transform(transform.InjectOwner(instance))
+	if err != nil {
+		return err
+	}
+	if err := util.ApplyManifest(ctx, r.Client, manifest); err != nil {
+		logger.Error(err, "Unable to install RBAC of watched namespaces")
+		return err
+	}
+	return nil
+}
+
+// removeWatchedNamespacesRBAC removes the Roles and RoleBindings of all namespaces which are not in keepNamespaces
+func (r *KedaControllerReconciler) removeWatchedNamespacesRBAC(ctx context.Context, logger logr.Logger, keepNamespaces []string) error {
+	for _, kind := range []string{"RoleBinding", "Role"} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := r.Client.List(ctx, list, client.MatchingLabels{watchedNamespaceRBACLabel: watchedNamespaceRBACLabelValue}); err != nil {
+			logger.Error(err, "Unable to list RBAC of watched namespaces", "kind", kind)
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if slices.Contains(keepNamespaces, obj.GetNamespace()) {
+				continue
+			}
+			if err := r.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Unable to remove RBAC of a namespace no longer watched", "kind", kind, "namespace", obj.GetNamespace())
+				return err
+			}
+		}
+	}
+	return nil
+}
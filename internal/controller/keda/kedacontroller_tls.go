@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	mf "github.com/manifestival/manifestival"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+const clusterAPIServerName = "cluster"
+
+var apiServerGVK = configv1.GroupVersion.WithKind("APIServer")
+
+// tlsProfile returns the TLS security profile of the KEDA components, the one of the KedaController takes precedence
+// over the one of the OpenShift APIServer. It returns nil when neither is present, KEDA keeps its defaults then
+func (r *KedaControllerReconciler) tlsProfile(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController) (*configv1.TLSProfileSpec, error) {
+	if instance.Spec.TLSProfile != nil {
+		profile := util.ResolveTLSProfile(instance.Spec.TLSProfile)
+		return &profile, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(apiServerGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterAPIServerName}, u); err != nil {
+		// the APIServer config is only served by OpenShift
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Unable to get the APIServer config")
+		return nil, err
+	}
+	apiServer := &configv1.APIServer{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, apiServer); err != nil {
+		return nil, err
+	}
+	profile := util.ResolveTLSProfile(apiServer.Spec.TLSSecurityProfile)
+	return &profile, nil
+}
+
+// tlsProfileTransforms renders the TLS security profile into a KEDA component, KEDA Metrics Server takes the minimum
+// TLS version and the cipher suites as flags, KEDA Operator and KEDA Admission Webhooks only support the minimum TLS version
+func (r *KedaControllerReconciler) tlsProfileTransforms(ctx context.Context, logger logr.Logger, instance *kedav1alpha1.KedaController,
+	resource string) ([]mf.Transformer, error) {
+	profile, err := r.tlsProfile(ctx, logger, instance)
+	if err != nil || profile == nil {
+		return nil, err
+	}
+	if resource == "metricsserver" {
+		return transform.ReplaceMetricsServerTLSConfig(string(profile.MinTLSVersion), util.IANACipherSuites(profile.Ciphers), r.Scheme, logger), nil
+	}
+	return []mf.Transformer{transform.ReplaceMinTLSVersion(util.KedaMinTLSVersion(profile.MinTLSVersion), resource, r.Scheme)}, nil
+}
+
+// kedaControllerForAPIServer enqueues the KedaController when the OpenShift APIServer config changes
+func (r *KedaControllerReconciler) kedaControllerForAPIServer(_ context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != clusterAPIServerName {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: kedaControllerResourceName, Namespace: r.resourceNamespace}}}
+}
@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -148,6 +149,11 @@ func setupEnv(testEnv *envtest.Environment, scheme *runtime.Scheme) (manager ctr
 		return
 	}
 
+	err = apiregistrationv1.AddToScheme(scheme)
+	if err != nil {
+		return
+	}
+
 	// +kubebuilder:scaffold:scheme
 	manager, err = ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
@@ -1,10 +1,13 @@
 package transform
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	mf "github.com/manifestival/manifestival"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -14,27 +17,32 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
-)
 
-var (
-	logLevels        = []string{"debug", "info", "error"}
-	logEncoders      = []string{"json", "console"}
-	logTimeEncodings = []string{"epoch", "millis", "nano", "iso8601", "rfc3339", "rfc3339nano"}
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
 )
 
 type Prefix string
 
 const (
-	LogLevelArg           Prefix = "--zap-log-level="
-	LogEncoderArg         Prefix = "--zap-encoder="
-	LogTimeEncodingArg    Prefix = "--zap-time-encoding="
-	LogLevelMetricsServer Prefix = "--v="
-	ClientCAFile          Prefix = "--client-ca-file="
-	TLSCertFile           Prefix = "--tls-cert-file="
-	TLSPrivateKeyFile     Prefix = "--tls-private-key-file="
-	CertRotation          Prefix = "--enable-cert-rotation="
-	CADir                 Prefix = "--ca-dir="
+	LogLevelArg                 Prefix = "--zap-log-level="
+	LogEncoderArg               Prefix = "--zap-encoder="
+	LogTimeEncodingArg          Prefix = "--zap-time-encoding="
+	LogLevelMetricsServer       Prefix = "--v="
+	ClientCAFile                Prefix = "--client-ca-file="
+	TLSCertFile                 Prefix = "--tls-cert-file="
+	TLSPrivateKeyFile           Prefix = "--tls-private-key-file="
+	CertRotation                Prefix = "--enable-cert-rotation="
+	CADir                       Prefix = "--ca-dir="
+	KubeAPIQPS                  Prefix = "--kube-api-qps="
+	KubeAPIBurst                Prefix = "--kube-api-burst="
+	ProfilingBindAddress        Prefix = "--profiling-bind-address="
+	SecurePort                  Prefix = "--secure-port="
+	MetricsServiceAddress       Prefix = "--metrics-service-address="
+	MetricsServiceGRPCAuthority Prefix = "--metrics-service-grpc-authority="
+	TLSMinVersion               Prefix = "--tls-min-version="
+	TLSCipherSuites             Prefix = "--tls-cipher-suites="
 )
 
 func (p Prefix) String() string {
@@ -47,6 +55,13 @@ const (
 	containerNameMetricsServer     = "keda-metrics-apiserver"
 	containerNameAdmissionWebhooks = "keda-admission-webhooks"
 	caCertVolPrefix                = "cabundle"
+	trustedCAVolPrefix             = "trusted-cabundle"
+	customCADirPrefix              = "/custom/ca"
+	systemCADir                    = "/etc/ssl/certs"
+	sslCertDirEnvVar               = "SSL_CERT_DIR"
+	kedaVersionLabel               = "app.kubernetes.io/version"
+	httpTimeoutEnvVar              = "KEDA_HTTP_DEFAULT_TIMEOUT"
+	minTLSVersionEnvVar            = "KEDA_HTTP_MIN_TLS_VERSION"
 )
 
 // ReplaceAllNamespaces returns a transformer which will traverse the unstructured content looking for map entries with
@@ -102,6 +117,21 @@ func ReplaceNamespace(name string, namespace string, scheme *runtime.Scheme, log
 	}
 }
 
+// ReplaceClusterRoleRules replaces the rules of the ClusterRole with the given name
+func ReplaceClusterRoleRules(name string, rules []rbacv1.PolicyRule, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ClusterRole" || u.GetName() != name {
+			return nil
+		}
+		clusterRole := &rbacv1.ClusterRole{}
+		if err := scheme.Convert(u, clusterRole, nil); err != nil {
+			return err
+		}
+		clusterRole.Rules = rules
+		return scheme.Convert(clusterRole, u, nil)
+	}
+}
+
 func ReplaceWatchNamespace(watchNamespace string, containerName string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
 		changed := false
@@ -194,6 +224,45 @@ func EnsureCABundleInjectionForValidatingWebhookConfiguration(annotation string,
 	}
 }
 
+// EnsureCABundleForValidatingWebhookConfiguration sets the CA bundle verifying KEDA Admission Webhooks on every webhook
+func EnsureCABundleForValidatingWebhookConfiguration(caBundle []byte, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "ValidatingWebhookConfiguration" {
+			vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+			if err := scheme.Convert(u, vwc, nil); err != nil {
+				return err
+			}
+			for i := range vwc.Webhooks {
+				vwc.Webhooks[i].ClientConfig.CABundle = caBundle
+			}
+
+			if err := scheme.Convert(vwc, u, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// EnsureCABundleForAPIService sets the CA bundle verifying KEDA Metrics Server on its APIService
+func EnsureCABundleForAPIService(caBundle []byte, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "APIService" {
+			apiService := &apiregistrationv1.APIService{}
+			if err := scheme.Convert(u, apiService, nil); err != nil {
+				return err
+			}
+			apiService.Spec.CABundle = caBundle
+			apiService.Spec.InsecureSkipTLSVerify = false
+
+			if err := scheme.Convert(apiService, u, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 func EnsureCABundleInjectionForAPIService(annotation string, annotationValue string, scheme *runtime.Scheme) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() == "APIService" {
@@ -226,6 +295,36 @@ func EnsureCertInjectionForService(serviceName string, annotation string, annota
 	}
 }
 
+// ReplaceCertificatesSecret mounts the certificates of the Secret secretName instead of the ones generated by KEDA Operator
+// into every KEDA component
+func ReplaceCertificatesSecret(secretName string, scheme *runtime.Scheme) mf.Transformer {
+	return ReplaceCertificatesVolume(corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}, scheme)
+}
+
+// ReplaceCertificatesVolume replaces the source of the volume mounted at /certs into every KEDA component
+func ReplaceCertificatesVolume(source corev1.VolumeSource, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			volumes := deploy.Spec.Template.Spec.Volumes
+			for i := range volumes {
+				if volumes[i].Name == "certificates" {
+					volumes[i].VolumeSource = *source.DeepCopy()
+				}
+			}
+
+			if err := scheme.Convert(deploy, u, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 func MetricsServerEnsureCertificatesVolume(configMapName, secretName string, scheme *runtime.Scheme) mf.Transformer {
 	return ensureCertificatesVolumeForDeployment(containerNameMetricsServer, configMapName, secretName, scheme)
 }
@@ -536,94 +635,40 @@ func ensureCertificatesVolumeForDeployment(containerName, configMapName, secretN
 	}
 }
 
-// Add configmap volumes for configMapNames named cabundle0, cabundle1, etc. as /custom/ca0, /custom/ca1, etc. with
-// container args --ca-dir=/custom/ca0, --ca-dir=/custom/ca1, etc.
-func EnsureCACertsForOperatorDeployment(configMapNames []string, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
-	var retval []mf.Transformer
+// EnsureTrustedCAs mounts the ConfigMaps and Secrets with trusted CAs in sources into the container of a KEDA component
+// as /custom/ca0, /custom/ca1, etc. KEDA Operator loads them with --ca-dir=/custom/ca0, etc. args followed by --ca-dir args
+// for extraCADirs, the other components through SSL_CERT_DIR, which Go reads in addition to the CA bundle of the system
+func EnsureTrustedCAs(sources []corev1.VolumeSource, extraCADirs []string, resource string, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
+	containerName := containerNameForResource(resource)
+	// the metrics server and the webhooks already mount their serving CA as the volume cabundle
+	volumePrefix := trustedCAVolPrefix
+	if containerName == containerNameKedaOperator {
+		volumePrefix = caCertVolPrefix
+	}
 
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
 	var caDirs []string
-	for i := range configMapNames {
-		caDirs = append(caDirs, "/custom/ca"+strconv.Itoa(i))
+	for i, source := range sources {
+		name := volumePrefix + strconv.Itoa(i)
+		dir := customCADirPrefix + strconv.Itoa(i)
+		volumes = append(volumes, corev1.Volume{Name: name, VolumeSource: source})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: name, MountPath: dir, ReadOnly: true})
+		caDirs = append(caDirs, dir)
 	}
-	retval = append(retval, replaceContainerArgs(caDirs, CADir, containerNameKedaOperator, scheme, logger))
-
-	retval = append(retval, func(u *unstructured.Unstructured) error {
-		if u.GetKind() == "Deployment" {
-			deploy := &appsv1.Deployment{}
-			if err := scheme.Convert(u, deploy, nil); err != nil {
-				return err
-			}
-
-			// add Volumes referencing certs in ConfigMap
-			cabundleVolumes := map[string]corev1.Volume{}
-			cabundleVolumeMounts := map[string]corev1.VolumeMount{}
-			for i, configMapName := range configMapNames {
-				cabundleVolumes[configMapName] = corev1.Volume{
-					Name: caCertVolPrefix + strconv.Itoa(i),
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: configMapName,
-							},
-						},
-					},
-				}
-
-				cabundleVolumeMounts[caCertVolPrefix+strconv.Itoa(i)] = corev1.VolumeMount{
-					Name:      caCertVolPrefix + strconv.Itoa(i),
-					MountPath: "/custom/ca" + strconv.Itoa(i),
-				}
-			}
-
-			cabundleVolumeFound := map[string]bool{}
-			var volumes []corev1.Volume
-			for _, vol := range deploy.Spec.Template.Spec.Volumes {
-				if caVol, ok := cabundleVolumes[vol.Name]; ok {
-					volumes = append(volumes, caVol)
-					cabundleVolumeFound[vol.Name] = true
-				} else if !strings.HasPrefix(vol.Name, caCertVolPrefix) {
-					volumes = append(volumes, vol)
-				} // else don't copy it over since it shouldn't be there
-			}
-
-			for name, vol := range cabundleVolumes {
-				if !cabundleVolumeFound[name] {
-					volumes = append(volumes, vol)
-				}
-			}
-			deploy.Spec.Template.Spec.Volumes = volumes
-
-			containers := deploy.Spec.Template.Spec.Containers
-			for i := range containers {
-				if containers[i].Name == containerNameKedaOperator {
-					// mount Volumes referencing certs in ConfigMap
-					var volumeMounts []corev1.VolumeMount
-					cabundleVolumeMountFound := map[string]bool{}
-					for _, volMount := range containers[i].VolumeMounts {
-						if caVolMount, ok := cabundleVolumeMounts[volMount.Name]; ok {
-							volumeMounts = append(volumeMounts, caVolMount)
-							cabundleVolumeMountFound[volMount.Name] = true
-						} else if !strings.HasPrefix(volMount.Name, caCertVolPrefix) {
-							volumeMounts = append(volumeMounts, volMount)
-						} // else don't copy it over since it shouldn't be there
-					}
-					for name, volmount := range cabundleVolumeMounts {
-						if !cabundleVolumeMountFound[name] {
-							volumeMounts = append(volumeMounts, volmount)
-						}
-					}
-					containers[i].VolumeMounts = volumeMounts
-					break
-				}
-			}
-
-			if err := scheme.Convert(deploy, u, nil); err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-	return retval
+	caDirs = append(caDirs, extraCADirs...)
+
+	var transforms []mf.Transformer
+	if containerName == containerNameKedaOperator {
+		transforms = append(transforms, replaceContainerArgs(caDirs, CADir, containerName, scheme, logger))
+	} else if len(caDirs) > 0 {
+		env := []corev1.EnvVar{{Name: sslCertDirEnvVar, Value: strings.Join(append([]string{systemCADir}, caDirs...), ":")}}
+		transforms = append(transforms, replaceEnv(env, nil, containerName, scheme))
+	}
+	if len(volumes) > 0 {
+		transforms = append(transforms, AddVolumes(volumes, scheme, logger), addVolumeMounts(volumeMounts, containerName, scheme, logger))
+	}
+	return transforms
 }
 
 func EnsurePathsToCertsInDeployment(values []string, prefixes []Prefix, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
@@ -699,20 +744,8 @@ func EnsureAuditPolicyConfigMapMountsVolume(configMapName string, scheme *runtim
 }
 
 func ReplaceKedaOperatorLogLevel(logLevel string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, level := range logLevels {
-		if logLevel == level {
-			found = true
-		}
-	}
-	if !found {
-		if _, err := strconv.ParseUint(logLevel, 10, 64); err == nil {
-			found = true
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log level for KEDA Operator, it needs to be set to ", strings.Join(logLevels, ", "), "or an integer value greater than 0")
+	if !util.IsValidLogLevel(logLevel) {
+		logger.Info("Ignoring speficied Log level for KEDA Operator, it needs to be set to ", strings.Join(util.LogLevels, ", "), "or an integer value greater than 0")
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -723,16 +756,8 @@ func ReplaceKedaOperatorLogLevel(logLevel string, scheme *runtime.Scheme, logger
 }
 
 func ReplaceKedaOperatorLogEncoder(logEncoder string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, format := range logEncoders {
-		if logEncoder == format {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log encoder for KEDA Operator", "specified", logEncoder, "allowed values", strings.Join(logEncoders, ", "))
+	if !util.IsValidLogEncoder(logEncoder) {
+		logger.Info("Ignoring speficied Log encoder for KEDA Operator", "specified", logEncoder, "allowed values", strings.Join(util.LogEncoders, ", "))
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -743,12 +768,7 @@ func ReplaceKedaOperatorLogEncoder(logEncoder string, scheme *runtime.Scheme, lo
 }
 
 func ReplaceMetricsServerLogLevel(logLevel string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	if _, err := strconv.ParseUint(logLevel, 10, 64); err == nil {
-		found = true
-	}
-
-	if !found {
+	if !util.IsValidMetricsServerLogLevel(logLevel) {
 		logger.Info("Ignoring speficied Log level for KEDA Metrics Server, it needs to be set to an integer value greater than 0")
 		return func(*unstructured.Unstructured) error {
 			return nil
@@ -760,16 +780,8 @@ func ReplaceMetricsServerLogLevel(logLevel string, scheme *runtime.Scheme, logge
 }
 
 func ReplaceKedaOperatorLogTimeEncoding(logTimeEncoding string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, timeEncoding := range logTimeEncodings {
-		if logTimeEncoding == timeEncoding {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log time encoding for KEDA Operator", "specified", logTimeEncoding, "allowed values", strings.Join(logTimeEncodings, ", "))
+	if !util.IsValidLogTimeEncoding(logTimeEncoding) {
+		logger.Info("Ignoring speficied Log time encoding for KEDA Operator", "specified", logTimeEncoding, "allowed values", strings.Join(util.LogTimeEncodings, ", "))
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -780,20 +792,8 @@ func ReplaceKedaOperatorLogTimeEncoding(logTimeEncoding string, scheme *runtime.
 }
 
 func ReplaceAdmissionWebhooksLogLevel(logLevel string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, level := range logLevels {
-		if logLevel == level {
-			found = true
-		}
-	}
-	if !found {
-		if _, err := strconv.ParseUint(logLevel, 10, 64); err == nil {
-			found = true
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log level for KEDA Admission Webhooks, it needs to be set to ", strings.Join(logLevels, ", "), "or an integer value greater than 0")
+	if !util.IsValidLogLevel(logLevel) {
+		logger.Info("Ignoring speficied Log level for KEDA Admission Webhooks, it needs to be set to ", strings.Join(util.LogLevels, ", "), "or an integer value greater than 0")
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -804,16 +804,8 @@ func ReplaceAdmissionWebhooksLogLevel(logLevel string, scheme *runtime.Scheme, l
 }
 
 func ReplaceAdmissionWebhooksLogEncoder(logEncoder string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, format := range logEncoders {
-		if logEncoder == format {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log encoder for KEDA Admission Webhooks", "specified", logEncoder, "allowed values", strings.Join(logEncoders, ", "))
+	if !util.IsValidLogEncoder(logEncoder) {
+		logger.Info("Ignoring speficied Log encoder for KEDA Admission Webhooks", "specified", logEncoder, "allowed values", strings.Join(util.LogEncoders, ", "))
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -824,16 +816,8 @@ func ReplaceAdmissionWebhooksLogEncoder(logEncoder string, scheme *runtime.Schem
 }
 
 func ReplaceAdmissionWebhooksLogTimeEncoding(logTimeEncoding string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
-	found := false
-	for _, timeEncoding := range logTimeEncodings {
-		if logTimeEncoding == timeEncoding {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		logger.Info("Ignoring speficied Log time encoding for KEDA Admission Webhooks", "specified", logTimeEncoding, "allowed values", strings.Join(logTimeEncodings, ", "))
+	if !util.IsValidLogTimeEncoding(logTimeEncoding) {
+		logger.Info("Ignoring speficied Log time encoding for KEDA Admission Webhooks", "specified", logTimeEncoding, "allowed values", strings.Join(util.LogTimeEncodings, ", "))
 		return func(*unstructured.Unstructured) error {
 			return nil
 		}
@@ -1170,6 +1154,85 @@ func ReplaceAffinity(affinity *corev1.Affinity, scheme *runtime.Scheme) mf.Trans
 	}
 }
 
+func ReplaceTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			deploy.Spec.Template.Spec.TopologySpreadConstraints = constraints
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+func ReplaceReplicas(replicas int32, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			deploy.Spec.Replicas = &replicas
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// AddDefaultPodAntiAffinity prefers to schedule the pods of a Deployment on different nodes,
+// it is meant for Deployments running more than one replica without a user defined affinity
+func AddDefaultPodAntiAffinity(scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: deploy.Spec.Selector.DeepCopy(),
+							TopologyKey:   corev1.LabelHostname,
+						},
+					}},
+				},
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// AddDefaultTopologySpreadConstraints spreads the pods of a Deployment evenly across zones when possible,
+// it is meant for Deployments running more than one replica without user defined constraints
+func AddDefaultTopologySpreadConstraints(scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			deploy.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       corev1.LabelTopologyZone,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     deploy.Spec.Selector.DeepCopy(),
+			}}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
 func ReplacePriorityClassName(priorityClassName string, scheme *runtime.Scheme) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() == "Deployment" {
@@ -1222,15 +1285,22 @@ func ReplaceMetricsServerImage(image string, scheme *runtime.Scheme) mf.Transfor
 	return replaceContainerImage(image, containerNameMetricsServer, scheme)
 }
 
-func ReplaceKedaOperatorImage(image string, scheme *runtime.Scheme) mf.Transformer {
-	return replaceContainerImage(image, containerNameKedaOperator, scheme)
+func ReplaceKedaOperatorEnv(env []corev1.EnvVar, envFrom []corev1.EnvFromSource, scheme *runtime.Scheme) mf.Transformer {
+	return replaceEnv(env, envFrom, containerNameKedaOperator, scheme)
 }
 
-func ReplaceAdmissionWebhooksImage(image string, scheme *runtime.Scheme) mf.Transformer {
-	return replaceContainerImage(image, containerNameAdmissionWebhooks, scheme)
+func ReplaceMetricsServerEnv(env []corev1.EnvVar, envFrom []corev1.EnvFromSource, scheme *runtime.Scheme) mf.Transformer {
+	return replaceEnv(env, envFrom, containerNameMetricsServer, scheme)
 }
 
-func replaceContainerImage(image string, containerName string, scheme *runtime.Scheme) mf.Transformer {
+func ReplaceAdmissionWebhooksEnv(env []corev1.EnvVar, envFrom []corev1.EnvFromSource, scheme *runtime.Scheme) mf.Transformer {
+	return replaceEnv(env, envFrom, containerNameAdmissionWebhooks, scheme)
+}
+
+// replaceEnv merges env into the environment variables of the container, a variable with the same name
+// as an existing one replaces it, others are appended in the given order. envFrom sources are appended
+// after the existing ones, so their keys are overridden by the variables defined in env
+func replaceEnv(env []corev1.EnvVar, envFrom []corev1.EnvFromSource, containerName string, scheme *runtime.Scheme) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() == "Deployment" {
 			deploy := &appsv1.Deployment{}
@@ -1241,7 +1311,20 @@ func replaceContainerImage(image string, containerName string, scheme *runtime.S
 			containers := deploy.Spec.Template.Spec.Containers
 			for i, container := range containers {
 				if container.Name == containerName {
-					containers[i].Image = image
+					for _, envVar := range env {
+						found := false
+						for j := range containers[i].Env {
+							if containers[i].Env[j].Name == envVar.Name {
+								containers[i].Env[j] = envVar
+								found = true
+								break
+							}
+						}
+						if !found {
+							containers[i].Env = append(containers[i].Env, envVar)
+						}
+					}
+					containers[i].EnvFrom = append(containers[i].EnvFrom, envFrom...)
 					break
 				}
 			}
@@ -1251,58 +1334,536 @@ func replaceContainerImage(image string, containerName string, scheme *runtime.S
 	}
 }
 
-func EnsureAuditLogMount(pvc string, path string, scheme *runtime.Scheme) mf.Transformer {
-	const logOutputVolumeName = "audit-log"
+// ReplaceHTTPTimeout sets the default timeout of HTTP requests made by the KEDA component
+func ReplaceHTTPTimeout(timeout time.Duration, resource string, scheme *runtime.Scheme) mf.Transformer {
+	env := []corev1.EnvVar{{Name: httpTimeoutEnvVar, Value: strconv.FormatInt(timeout.Milliseconds(), 10)}}
+	return replaceEnv(env, nil, containerNameForResource(resource), scheme)
+}
+
+// ReplaceMinTLSVersion sets the minimum TLS version of KEDA Operator or KEDA Admission Webhooks, e.g. 'TLS12'.
+// KEDA applies it to its TLS servers as well as to the connections of the scalers
+func ReplaceMinTLSVersion(version string, resource string, scheme *runtime.Scheme) mf.Transformer {
+	env := []corev1.EnvVar{{Name: minTLSVersionEnvVar, Value: version}}
+	return replaceEnv(env, nil, containerNameForResource(resource), scheme)
+}
+
+// ReplaceMetricsServerTLSConfig sets the minimum TLS version, e.g. 'VersionTLS12', and the cipher suites of
+// KEDA Metrics Server, no cipher suites keep the default ones
+func ReplaceMetricsServerTLSConfig(minVersion string, cipherSuites []string, scheme *runtime.Scheme, logger logr.Logger) []mf.Transformer {
+	transforms := []mf.Transformer{replaceContainerArg(minVersion, TLSMinVersion, containerNameMetricsServer, scheme, logger)}
+	if len(cipherSuites) > 0 {
+		transforms = append(transforms, replaceContainerArg(strings.Join(cipherSuites, ","), TLSCipherSuites, containerNameMetricsServer, scheme, logger))
+	}
+	return transforms
+}
+
+func ReplaceKubeAPIQPS(qps int32, resource string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return requireKedaVersion("2.10.0", "kubeAPIQPS",
+		replaceContainerArg(strconv.Itoa(int(qps)), KubeAPIQPS, containerNameForResource(resource), scheme, logger))
+}
+
+func ReplaceKubeAPIBurst(burst int32, resource string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return requireKedaVersion("2.10.0", "kubeAPIBurst",
+		replaceContainerArg(strconv.Itoa(int(burst)), KubeAPIBurst, containerNameForResource(resource), scheme, logger))
+}
+
+func ReplaceProfilingBindAddress(address string, resource string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return requireKedaVersion("2.14.0", "profilingBindAddress",
+		replaceContainerArg(address, ProfilingBindAddress, containerNameForResource(resource), scheme, logger))
+}
+
+func ReplaceMetricsServiceAddress(address string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return replaceContainerArg(address, MetricsServiceAddress, containerNameMetricsServer, scheme, logger)
+}
+
+func ReplaceMetricsServiceGRPCAuthority(authority string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return requireKedaVersion("2.14.0", "grpc.metricsServiceAuthority",
+		replaceContainerArg(authority, MetricsServiceGRPCAuthority, containerNameMetricsServer, scheme, logger))
+}
+
+// ReplaceMetricsServerSecurePort moves the external metrics API of KEDA Metrics Server to port, along with
+// its container port, probes and the target port of its Service
+func ReplaceMetricsServerSecurePort(port int32, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	replaceArg := replaceContainerArg(strconv.Itoa(int(port)), SecurePort, containerNameMetricsServer, scheme, logger)
 	return func(u *unstructured.Unstructured) error {
-		// ensure mountVolume exists when volume exists
-		if u.GetKind() == "Deployment" {
+		switch {
+		case u.GetKind() == "Deployment":
+			if err := replaceArg(u); err != nil {
+				return err
+			}
 			deploy := &appsv1.Deployment{}
 			if err := scheme.Convert(u, deploy, nil); err != nil {
 				return err
 			}
 
-			logOutVolume := corev1.Volume{
-				Name: logOutputVolumeName,
-				VolumeSource: corev1.VolumeSource{
-					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-						ClaimName: pvc,
-					},
-				},
-			}
-			// find volume by name
-			volumes := deploy.Spec.Template.Spec.Volumes
-			logVolumeFound := false
-			for i := range volumes {
-				if volumes[i].Name == logOutputVolumeName {
-					volumes[i] = logOutVolume
-					logVolumeFound = true
-				}
-			}
-
-			// if not found
-			if !logVolumeFound {
-				deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, logOutVolume)
-			}
-
 			containers := deploy.Spec.Template.Spec.Containers
 			for i := range containers {
 				if containers[i].Name == containerNameMetricsServer {
-					auditVolumeMount := corev1.VolumeMount{
-						Name:      logOutputVolumeName,
-						MountPath: path,
+					for j := range containers[i].Ports {
+						if containers[i].Ports[j].Name == "https" {
+							containers[i].Ports[j].ContainerPort = port
+						}
 					}
-
-					volumeMounts := containers[i].VolumeMounts
-					auditLogVolumeMountFound := false
-					for j := range volumeMounts {
-						if volumeMounts[j].Name == logOutputVolumeName {
-							volumeMounts[j] = auditVolumeMount
-							auditLogVolumeMountFound = true
+					for _, probe := range []*corev1.Probe{containers[i].LivenessProbe, containers[i].ReadinessProbe} {
+						if probe != nil && probe.HTTPGet != nil && probe.HTTPGet.Scheme == corev1.URISchemeHTTPS {
+							probe.HTTPGet.Port = intstr.FromInt32(port)
 						}
 					}
-					// add VolumeMount to deployment if not found
-					if !auditLogVolumeMountFound {
-						containers[i].VolumeMounts = append(containers[i].VolumeMounts, auditVolumeMount)
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		case u.GetKind() == "Service" && u.GetName() == containerNameMetricsServer:
+			service := &corev1.Service{}
+			if err := scheme.Convert(u, service, nil); err != nil {
+				return err
+			}
+
+			for i := range service.Spec.Ports {
+				if service.Spec.Ports[i].Name == "https" {
+					service.Spec.Ports[i].TargetPort = intstr.FromInt32(port)
+				}
+			}
+			return scheme.Convert(service, u, nil)
+		}
+		return nil
+	}
+}
+
+// requireKedaVersion fails the transformation of a Deployment with a KEDA version older than minVersion,
+// which doesn't support setting yet. Deployments with a version which isn't semver, e.g. 'main', are transformed
+func requireKedaVersion(minVersion string, setting string, transformer mf.Transformer) mf.Transformer {
+	required := semver.MustParse(minVersion)
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			if v, found := u.GetLabels()[kedaVersionLabel]; found {
+				if installed, err := semver.ParseTolerant(v); err == nil && installed.LT(required) {
+					return fmt.Errorf("%s requires KEDA %s or newer, the installed version is %s", setting, minVersion, v)
+				}
+			}
+		}
+		return transformer(u)
+	}
+}
+
+func containerNameForResource(resource string) string {
+	switch resource {
+	case "operator":
+		return containerNameKedaOperator
+	case "metricsserver":
+		return containerNameMetricsServer
+	case "admissionwebhooks":
+		return containerNameAdmissionWebhooks
+	default:
+		return ""
+	}
+}
+
+// AddVolumes adds volumes to the pod of a Deployment, a volume with the name of an existing volume is skipped
+// so that the volumes the component depends on can't be replaced
+func AddVolumes(volumes []corev1.Volume, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			podSpec := &deploy.Spec.Template.Spec
+			podSpec.Volumes = mergeByName(podSpec.Volumes, volumes, func(v corev1.Volume) string { return v.Name }, "volume", logger)
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+func AddKedaOperatorVolumeMounts(volumeMounts []corev1.VolumeMount, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return addVolumeMounts(volumeMounts, containerNameKedaOperator, scheme, logger)
+}
+
+func AddMetricsServerVolumeMounts(volumeMounts []corev1.VolumeMount, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return addVolumeMounts(volumeMounts, containerNameMetricsServer, scheme, logger)
+}
+
+func AddAdmissionWebhooksVolumeMounts(volumeMounts []corev1.VolumeMount, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return addVolumeMounts(volumeMounts, containerNameAdmissionWebhooks, scheme, logger)
+}
+
+// addVolumeMounts adds volumeMounts to the container, a mount to a path the container already mounts a volume to is skipped
+func addVolumeMounts(volumeMounts []corev1.VolumeMount, containerName string, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i, container := range containers {
+				if container.Name == containerName {
+					containers[i].VolumeMounts = mergeByName(containers[i].VolumeMounts, volumeMounts,
+						func(m corev1.VolumeMount) string { return m.MountPath }, "volumeMount", logger)
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// AddInitContainers adds init containers to the pod of a Deployment, an init container with the name
+// of an existing one is skipped
+func AddInitContainers(initContainers []corev1.Container, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			podSpec := &deploy.Spec.Template.Spec
+			podSpec.InitContainers = mergeByName(podSpec.InitContainers, initContainers, func(c corev1.Container) string { return c.Name }, "initContainer", logger)
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// AddSidecarContainers adds containers to the pod of a Deployment, a container with the name of an existing one
+// is skipped so that the component container can't be replaced
+func AddSidecarContainers(sidecars []corev1.Container, scheme *runtime.Scheme, logger logr.Logger) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			podSpec := &deploy.Spec.Template.Spec
+			podSpec.Containers = mergeByName(podSpec.Containers, sidecars, func(c corev1.Container) string { return c.Name }, "sidecar", logger)
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// mergeByName appends the items of added to existing, unless an item with the same key is already present
+// in existing, in which case the existing item is kept
+func mergeByName[T any](existing []T, added []T, key func(T) string, kind string, logger logr.Logger) []T {
+	keys := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		keys[key(item)] = true
+	}
+	for _, item := range added {
+		if keys[key(item)] {
+			logger.Info("Skipping user-defined item conflicting with the component", "kind", kind, "name", key(item))
+			continue
+		}
+		keys[key(item)] = true
+		existing = append(existing, item)
+	}
+	return existing
+}
+
+// ReplacePodSecurityContext sets the fields of the pod security context of a Deployment which are set in securityContext
+func ReplacePodSecurityContext(securityContext *corev1.PodSecurityContext, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			podSpec := &deploy.Spec.Template.Spec
+			if podSpec.SecurityContext == nil {
+				podSpec.SecurityContext = &corev1.PodSecurityContext{}
+			}
+			overlayFields(podSpec.SecurityContext, securityContext)
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+func ReplaceKedaOperatorSecurityContext(securityContext *corev1.SecurityContext, scheme *runtime.Scheme) mf.Transformer {
+	return replaceContainerSecurityContext(securityContext, containerNameKedaOperator, scheme)
+}
+
+func ReplaceMetricsServerSecurityContext(securityContext *corev1.SecurityContext, scheme *runtime.Scheme) mf.Transformer {
+	return replaceContainerSecurityContext(securityContext, containerNameMetricsServer, scheme)
+}
+
+func ReplaceAdmissionWebhooksSecurityContext(securityContext *corev1.SecurityContext, scheme *runtime.Scheme) mf.Transformer {
+	return replaceContainerSecurityContext(securityContext, containerNameAdmissionWebhooks, scheme)
+}
+
+func replaceContainerSecurityContext(securityContext *corev1.SecurityContext, containerName string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i, container := range containers {
+				if container.Name == containerName {
+					if containers[i].SecurityContext == nil {
+						containers[i].SecurityContext = &corev1.SecurityContext{}
+					}
+					overlayFields(containers[i].SecurityContext, securityContext)
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+func ReplaceKedaOperatorProbes(livenessProbe, readinessProbe *corev1.Probe, scheme *runtime.Scheme) mf.Transformer {
+	return replaceProbes(livenessProbe, readinessProbe, containerNameKedaOperator, scheme)
+}
+
+func ReplaceMetricsServerProbes(livenessProbe, readinessProbe *corev1.Probe, scheme *runtime.Scheme) mf.Transformer {
+	return replaceProbes(livenessProbe, readinessProbe, containerNameMetricsServer, scheme)
+}
+
+func ReplaceAdmissionWebhooksProbes(livenessProbe, readinessProbe *corev1.Probe, scheme *runtime.Scheme) mf.Transformer {
+	return replaceProbes(livenessProbe, readinessProbe, containerNameAdmissionWebhooks, scheme)
+}
+
+// replaceProbes sets the fields of the probes of the container which are set in livenessProbe and readinessProbe,
+// either of them may be nil
+func replaceProbes(livenessProbe, readinessProbe *corev1.Probe, containerName string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i, container := range containers {
+				if container.Name == containerName {
+					if livenessProbe != nil {
+						if containers[i].LivenessProbe == nil {
+							containers[i].LivenessProbe = &corev1.Probe{}
+						}
+						overlayFields(containers[i].LivenessProbe, livenessProbe)
+					}
+					if readinessProbe != nil {
+						if containers[i].ReadinessProbe == nil {
+							containers[i].ReadinessProbe = &corev1.Probe{}
+						}
+						overlayFields(containers[i].ReadinessProbe, readinessProbe)
+					}
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// overlayFields sets the fields of the struct dst points to, to the fields of src which don't have their zero value,
+// dst and src have to be pointers to the same struct type
+func overlayFields[T any](dst *T, src *T) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src).Elem()
+	for i := 0; i < srcValue.NumField(); i++ {
+		if field := srcValue.Field(i); !field.IsZero() {
+			dstValue.Field(i).Set(field)
+		}
+	}
+}
+
+func ReplaceKedaOperatorImage(image string, scheme *runtime.Scheme) mf.Transformer {
+	return replaceContainerImage(image, containerNameKedaOperator, scheme)
+}
+
+func ReplaceAdmissionWebhooksImage(image string, scheme *runtime.Scheme) mf.Transformer {
+	return replaceContainerImage(image, containerNameAdmissionWebhooks, scheme)
+}
+
+func ReplaceKedaOperatorImagePullPolicy(policy corev1.PullPolicy, scheme *runtime.Scheme) mf.Transformer {
+	return replaceImagePullPolicy(policy, containerNameKedaOperator, scheme)
+}
+
+func ReplaceMetricsServerImagePullPolicy(policy corev1.PullPolicy, scheme *runtime.Scheme) mf.Transformer {
+	return replaceImagePullPolicy(policy, containerNameMetricsServer, scheme)
+}
+
+func ReplaceAdmissionWebhooksImagePullPolicy(policy corev1.PullPolicy, scheme *runtime.Scheme) mf.Transformer {
+	return replaceImagePullPolicy(policy, containerNameAdmissionWebhooks, scheme)
+}
+
+func replaceImagePullPolicy(policy corev1.PullPolicy, containerName string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i, container := range containers {
+				if container.Name == containerName {
+					containers[i].ImagePullPolicy = policy
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+func ReplaceImagePullSecrets(secrets []corev1.LocalObjectReference, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			deploy.Spec.Template.Spec.ImagePullSecrets = secrets
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// ReplaceImageRegistry replaces the registry of the images of all containers of a Deployment with mirror
+func ReplaceImageRegistry(mirror string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			podSpec := &deploy.Spec.Template.Spec
+			for i := range podSpec.InitContainers {
+				podSpec.InitContainers[i].Image = imageWithRegistry(podSpec.InitContainers[i].Image, mirror)
+			}
+			for i := range podSpec.Containers {
+				podSpec.Containers[i].Image = imageWithRegistry(podSpec.Containers[i].Image, mirror)
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// imageWithRegistry replaces the registry of image with registry, the first component of the image
+// is considered a registry if it contains a '.' or ':' or is 'localhost', otherwise the image is from Docker Hub
+func imageWithRegistry(image string, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	if first, rest, found := strings.Cut(image, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		return registry + "/" + rest
+	}
+	if !strings.Contains(image, "/") {
+		// official Docker Hub images live in the library namespace
+		return registry + "/library/" + image
+	}
+	return registry + "/" + image
+}
+
+func replaceContainerImage(image string, containerName string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i, container := range containers {
+				if container.Name == containerName {
+					containers[i].Image = image
+					break
+				}
+			}
+			return scheme.Convert(deploy, u, nil)
+		}
+		return nil
+	}
+}
+
+// the volume KEDA Metrics Server writes its audit log to
+const logOutputVolumeName = "audit-log"
+
+// AuditLogMountPath returns the path the audit log volume is mounted at in KEDA Metrics Server,
+// or an empty string when it is not mounted
+func AuditLogMountPath(deploy *appsv1.Deployment) string {
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		if container.Name != containerNameMetricsServer {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == logOutputVolumeName {
+				return mount.MountPath
+			}
+		}
+	}
+	return ""
+}
+
+func EnsureAuditLogMount(pvc string, path string, scheme *runtime.Scheme) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		// ensure mountVolume exists when volume exists
+		if u.GetKind() == "Deployment" {
+			deploy := &appsv1.Deployment{}
+			if err := scheme.Convert(u, deploy, nil); err != nil {
+				return err
+			}
+
+			logOutVolume := corev1.Volume{
+				Name: logOutputVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc,
+					},
+				},
+			}
+			// find volume by name
+			volumes := deploy.Spec.Template.Spec.Volumes
+			logVolumeFound := false
+			for i := range volumes {
+				if volumes[i].Name == logOutputVolumeName {
+					volumes[i] = logOutVolume
+					logVolumeFound = true
+				}
+			}
+
+			// if not found
+			if !logVolumeFound {
+				deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, logOutVolume)
+			}
+
+			containers := deploy.Spec.Template.Spec.Containers
+			for i := range containers {
+				if containers[i].Name == containerNameMetricsServer {
+					auditVolumeMount := corev1.VolumeMount{
+						Name:      logOutputVolumeName,
+						MountPath: path,
+					}
+
+					volumeMounts := containers[i].VolumeMounts
+					auditLogVolumeMountFound := false
+					for j := range volumeMounts {
+						if volumeMounts[j].Name == logOutputVolumeName {
+							volumeMounts[j] = auditVolumeMount
+							auditLogVolumeMountFound = true
+						}
+					}
+					// add VolumeMount to deployment if not found
+					if !auditLogVolumeMountFound {
+						containers[i].VolumeMounts = append(containers[i].VolumeMounts, auditVolumeMount)
 					}
 					break
 				}
@@ -1328,3 +1889,117 @@ func InjectOwner(owner mf.Owner) mf.Transformer {
 		return nil
 	}
 }
+
+// monitorEndpointsField returns the field holding the scraped endpoints of a ServiceMonitor or PodMonitor
+func monitorEndpointsField(kind string) string {
+	switch kind {
+	case "ServiceMonitor":
+		return "endpoints"
+	case "PodMonitor":
+		return "podMetricsEndpoints"
+	default:
+		return ""
+	}
+}
+
+// AddMonitorLabels adds labels to ServiceMonitors and PodMonitors, e.g. to match the serviceMonitorSelector
+// of a Prometheus, the labels of the manifest are kept
+func AddMonitorLabels(labels map[string]string) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if monitorEndpointsField(u.GetKind()) == "" {
+			return nil
+		}
+		monitorLabels := u.GetLabels()
+		if monitorLabels == nil {
+			monitorLabels = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			if _, found := monitorLabels[key]; !found {
+				monitorLabels[key] = value
+			}
+		}
+		u.SetLabels(monitorLabels)
+		return nil
+	}
+}
+
+// AddLabels adds labels to all resources, they override the labels of the manifest
+func AddLabels(labels map[string]string) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		resourceLabels := u.GetLabels()
+		if resourceLabels == nil {
+			resourceLabels = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			resourceLabels[key] = value
+		}
+		u.SetLabels(resourceLabels)
+		return nil
+	}
+}
+
+// ReplaceMonitorEndpoints sets the scrape interval, the scrape timeout and the relabelings of all endpoints
+// of ServiceMonitors and PodMonitors, empty values keep the ones of the manifest
+func ReplaceMonitorEndpoints(interval, scrapeTimeout string, relabelings []map[string]interface{}) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		field := monitorEndpointsField(u.GetKind())
+		if field == "" {
+			return nil
+		}
+		endpoints, found, err := unstructured.NestedSlice(u.Object, "spec", field)
+		if err != nil || !found {
+			return err
+		}
+
+		for i := range endpoints {
+			endpoint, ok := endpoints[i].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected endpoint in %s %s", u.GetKind(), u.GetName())
+			}
+			if interval != "" {
+				endpoint["interval"] = interval
+			}
+			if scrapeTimeout != "" {
+				endpoint["scrapeTimeout"] = scrapeTimeout
+			}
+			if len(relabelings) > 0 {
+				items := make([]interface{}, 0, len(relabelings))
+				for _, relabeling := range relabelings {
+					items = append(items, runtime.DeepCopyJSON(relabeling))
+				}
+				endpoint["relabelings"] = items
+			}
+		}
+		return unstructured.SetNestedSlice(u.Object, endpoints, "spec", field)
+	}
+}
+
+// ReplaceMonitorAuthorization sets the TLS configuration and the bearer token Secret of all endpoints of ServiceMonitors
+// and PodMonitors, a TLS configuration scrapes the endpoints over HTTPS. Nil values keep the ones of the manifest
+func ReplaceMonitorAuthorization(tlsConfig, bearerTokenSecret map[string]interface{}) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		field := monitorEndpointsField(u.GetKind())
+		if field == "" {
+			return nil
+		}
+		endpoints, found, err := unstructured.NestedSlice(u.Object, "spec", field)
+		if err != nil || !found {
+			return err
+		}
+
+		for i := range endpoints {
+			endpoint, ok := endpoints[i].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected endpoint in %s %s", u.GetKind(), u.GetName())
+			}
+			if tlsConfig != nil {
+				endpoint["scheme"] = "https"
+				endpoint["tlsConfig"] = runtime.DeepCopyJSON(tlsConfig)
+			}
+			if bearerTokenSecret != nil {
+				endpoint["bearerTokenSecret"] = runtime.DeepCopyJSON(bearerTokenSecret)
+			}
+		}
+		return unstructured.SetNestedSlice(u.Object, endpoints, "spec", field)
+	}
+}
@@ -18,11 +18,18 @@ package transform_test
 
 import (
 	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	mf "github.com/manifestival/manifestival"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/kedacore/keda-olm-operator/internal/controller/keda/transform"
 )
@@ -105,3 +112,939 @@ spec:
 		})
 	})
 })
+
+var _ = Describe("Transforming the rules of a ClusterRole", func() {
+	yamlData := `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: keda-operator
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: keda-external-metrics-reader
+rules:
+- apiGroups:
+  - external.metrics.k8s.io
+  resources:
+  - '*'
+  verbs:
+  - '*'
+`
+
+	It("Should replace the rules of the named ClusterRole only", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+		rules := []rbacv1.PolicyRule{{APIGroups: []string{"apiregistration.k8s.io"}, Resources: []string{"apiservices"}, Verbs: []string{"get"}}}
+		newManifest, err := manifest.Transform(transform.ReplaceClusterRoleRules("keda-operator", rules, scheme.Scheme))
+		Expect(err).To(BeNil())
+
+		clusterRole := &rbacv1.ClusterRole{}
+		Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], clusterRole, nil)).To(Succeed())
+		Expect(clusterRole.Rules).To(Equal(rules))
+		Expect(newManifest.Resources()[1]).To(Equal(manifest.Resources()[1]))
+	})
+})
+
+var _ = Describe("Transforming Deployments for high availability", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-metrics-apiserver
+  namespace: keda
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: keda-metrics-apiserver
+  template:
+    metadata:
+      labels:
+        app: keda-metrics-apiserver
+    spec:
+      containers:
+      - name: keda-metrics-apiserver
+        image: ghcr.io/kedacore/keda-metrics-apiserver:main
+`
+	transformDeployment := func(transforms ...mf.Transformer) *appsv1.Deployment {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		Expect(err).To(BeNil())
+
+		r := newManifest.Resources()
+		Expect(len(r)).To(Equal(1))
+		deploy := &appsv1.Deployment{}
+		Expect(scheme.Scheme.Convert(&r[0], deploy, nil)).To(Succeed())
+		return deploy
+	}
+
+	Context("When setting the number of replicas", func() {
+		It("Should replace spec.replicas", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			deploy := transformDeployment(transform.ReplaceReplicas(3, scheme.Scheme))
+			Expect(*deploy.Spec.Replicas).To(Equal(int32(3)))
+		})
+	})
+
+	Context("When adding the default scheduling constraints", func() {
+		It("Should spread the pods across nodes and zones using the Deployment selector", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			deploy := transformDeployment(
+				transform.AddDefaultPodAntiAffinity(scheme.Scheme),
+				transform.AddDefaultTopologySpreadConstraints(scheme.Scheme),
+			)
+
+			terms := deploy.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			Expect(terms).To(HaveLen(1))
+			Expect(terms[0].PodAffinityTerm.TopologyKey).To(Equal(corev1.LabelHostname))
+			Expect(terms[0].PodAffinityTerm.LabelSelector.MatchLabels).To(Equal(map[string]string{"app": "keda-metrics-apiserver"}))
+
+			constraints := deploy.Spec.Template.Spec.TopologySpreadConstraints
+			Expect(constraints).To(HaveLen(1))
+			Expect(constraints[0].TopologyKey).To(Equal(corev1.LabelTopologyZone))
+			Expect(constraints[0].WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+			Expect(constraints[0].LabelSelector.MatchLabels).To(Equal(map[string]string{"app": "keda-metrics-apiserver"}))
+		})
+	})
+})
+
+var _ = Describe("Transforming the environment of a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-operator
+  template:
+    metadata:
+      labels:
+        app: keda-operator
+    spec:
+      containers:
+      - name: keda-operator
+        image: ghcr.io/kedacore/keda:main
+        env:
+        - name: WATCH_NAMESPACE
+          value: ""
+        - name: KEDA_HTTP_DEFAULT_TIMEOUT
+          value: "3000"
+`
+	Context("When adding env and envFrom to the KEDA operator", func() {
+		It("Should merge the variables by name and append the sources", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			env := []corev1.EnvVar{
+				{Name: "KEDA_HTTP_DEFAULT_TIMEOUT", Value: "5000"},
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+			}
+			envFrom := []corev1.EnvFromSource{
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "keda-env"}}},
+			}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.ReplaceKedaOperatorEnv(env, envFrom, scheme.Scheme))
+			Expect(err).To(BeNil())
+
+			deploy := &appsv1.Deployment{}
+			Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+			container := deploy.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(Equal([]corev1.EnvVar{
+				{Name: "WATCH_NAMESPACE", Value: ""},
+				{Name: "KEDA_HTTP_DEFAULT_TIMEOUT", Value: "5000"},
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+			}))
+			Expect(container.EnvFrom).To(Equal(envFrom))
+		})
+
+		It("Should leave the containers of other components alone", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.ReplaceMetricsServerEnv([]corev1.EnvVar{{Name: "FOO", Value: "bar"}}, nil, scheme.Scheme))
+			Expect(err).To(BeNil())
+
+			deploy := &appsv1.Deployment{}
+			Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+			Expect(deploy.Spec.Template.Spec.Containers[0].Env).To(HaveLen(2))
+		})
+	})
+})
+
+var _ = Describe("Transforming the trusted CAs of a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-metrics-apiserver
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-metrics-apiserver
+  template:
+    metadata:
+      labels:
+        app: keda-metrics-apiserver
+    spec:
+      containers:
+      - name: keda-metrics-apiserver
+        image: ghcr.io/kedacore/keda-metrics-apiserver:main
+        volumeMounts:
+        - name: cabundle
+          mountPath: /cabundle
+      volumes:
+      - name: cabundle
+        configMap:
+          name: keda-ocp-cabundle
+`
+	sources := []corev1.VolumeSource{
+		{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "ca-configmap"}}},
+		{Secret: &corev1.SecretVolumeSource{SecretName: "ca-secret"}},
+	}
+
+	Context("When mounting CA sources into KEDA Metrics Server", func() {
+		It("Should keep the serving CA and point SSL_CERT_DIR to the system and the custom CAs", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.EnsureTrustedCAs(sources, nil, "metricsserver", scheme.Scheme, logr.Discard())...)
+			Expect(err).To(BeNil())
+
+			deploy := &appsv1.Deployment{}
+			Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+			podSpec := deploy.Spec.Template.Spec
+			Expect(podSpec.Volumes).To(Equal([]corev1.Volume{
+				{Name: "cabundle", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "keda-ocp-cabundle"}}}},
+				{Name: "trusted-cabundle0", VolumeSource: sources[0]},
+				{Name: "trusted-cabundle1", VolumeSource: sources[1]},
+			}))
+			Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "cabundle", MountPath: "/cabundle"},
+				{Name: "trusted-cabundle0", MountPath: "/custom/ca0", ReadOnly: true},
+				{Name: "trusted-cabundle1", MountPath: "/custom/ca1", ReadOnly: true},
+			}))
+			Expect(podSpec.Containers[0].Env).To(ConsistOf(corev1.EnvVar{Name: "SSL_CERT_DIR", Value: "/etc/ssl/certs:/custom/ca0:/custom/ca1"}))
+		})
+
+		It("Should leave the Deployment alone without CA sources", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.EnsureTrustedCAs(nil, nil, "metricsserver", scheme.Scheme, logr.Discard())...)
+			Expect(err).To(BeNil())
+			Expect(newManifest.Resources()[0].Object).To(Equal(manifest.Resources()[0].Object))
+		})
+	})
+})
+
+var _ = Describe("Transforming the certificates of a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-operator
+  template:
+    metadata:
+      labels:
+        app: keda-operator
+    spec:
+      containers:
+      - name: keda-operator
+        image: ghcr.io/kedacore/keda:main
+        volumeMounts:
+        - mountPath: /certs
+          name: certificates
+          readOnly: true
+      volumes:
+      - name: certificates
+        secret:
+          optional: true
+          secretName: kedaorg-certs
+`
+	Context("When the certificates are issued by cert-manager", func() {
+		It("Should mount the Secret of the Certificate", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.ReplaceCertificatesSecret("keda-cert-manager-certs", scheme.Scheme))
+			Expect(err).To(BeNil())
+
+			deploy := &appsv1.Deployment{}
+			Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+			Expect(deploy.Spec.Template.Spec.Volumes).To(Equal([]corev1.Volume{{
+				Name:         "certificates",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "keda-cert-manager-certs"}},
+			}}))
+			Expect(deploy.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		})
+	})
+
+	Context("When the certificates are provided by the user", func() {
+		It("Should mount the projected Secrets", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			source := corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "grpc-certs"},
+					Items:                []corev1.KeyToPath{{Key: "tls.crt", Path: "tls.crt"}, {Key: "tls.key", Path: "tls.key"}},
+				}},
+				{ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+					Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+				}},
+			}}}
+
+			manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+			Expect(err).To(BeNil())
+			newManifest, err := manifest.Transform(transform.ReplaceCertificatesVolume(source, scheme.Scheme))
+			Expect(err).To(BeNil())
+
+			deploy := &appsv1.Deployment{}
+			Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+			Expect(deploy.Spec.Template.Spec.Volumes).To(Equal([]corev1.Volume{{Name: "certificates", VolumeSource: source}}))
+		})
+	})
+})
+
+var _ = Describe("Transforming the runtime settings of KEDA components", func() {
+	yamlData := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: keda-metrics-apiserver
+  namespace: keda
+spec:
+  ports:
+  - name: https
+    port: 443
+    targetPort: 6443
+  - name: metrics
+    port: 8080
+    targetPort: 8080
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app.kubernetes.io/version: 2.12.0
+  name: keda-metrics-apiserver
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-metrics-apiserver
+  template:
+    metadata:
+      labels:
+        app: keda-metrics-apiserver
+    spec:
+      containers:
+      - name: keda-metrics-apiserver
+        image: ghcr.io/kedacore/keda-metrics-apiserver:2.12.0
+        args:
+        - /usr/local/bin/keda-adapter
+        - --secure-port=6443
+        env:
+        - name: KEDA_HTTP_DEFAULT_TIMEOUT
+          value: ""
+        ports:
+        - containerPort: 6443
+          name: https
+        - containerPort: 8080
+          name: http
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 6443
+            scheme: HTTPS
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: 6443
+            scheme: HTTPS
+`
+	transformManifest := func(transforms ...mf.Transformer) (*corev1.Service, *appsv1.Deployment, error) {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		r := newManifest.Resources()
+		Expect(len(r)).To(Equal(2))
+		service := &corev1.Service{}
+		Expect(scheme.Scheme.Convert(&r[0], service, nil)).To(Succeed())
+		deploy := &appsv1.Deployment{}
+		Expect(scheme.Scheme.Convert(&r[1], deploy, nil)).To(Succeed())
+		return service, deploy, nil
+	}
+
+	Context("When setting the HTTP timeout and the Kubernetes client limits", func() {
+		It("Should render the environment variable and flags", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			_, deploy, err := transformManifest(
+				transform.ReplaceHTTPTimeout(5*time.Second, "metricsserver", scheme.Scheme),
+				transform.ReplaceKubeAPIQPS(40, "metricsserver", scheme.Scheme, logr.Discard()),
+				transform.ReplaceKubeAPIBurst(60, "metricsserver", scheme.Scheme, logr.Discard()),
+			)
+			Expect(err).To(BeNil())
+
+			container := deploy.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "KEDA_HTTP_DEFAULT_TIMEOUT", Value: "5000"}))
+			Expect(container.Args).To(ContainElements("--kube-api-qps=40", "--kube-api-burst=60"))
+		})
+	})
+
+	Context("When setting a flag the installed KEDA version doesn't support", func() {
+		It("Should fail the transformation", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			_, _, err := transformManifest(transform.ReplaceProfilingBindAddress(":8082", "metricsserver", scheme.Scheme, logr.Discard()))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("profilingBindAddress requires KEDA 2.14.0 or newer"))
+		})
+	})
+
+	Context("When changing the secure port of the Metrics Server", func() {
+		It("Should move the flag, container port, probes and Service target port", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			service, deploy, err := transformManifest(transform.ReplaceMetricsServerSecurePort(7443, scheme.Scheme, logr.Discard()))
+			Expect(err).To(BeNil())
+
+			container := deploy.Spec.Template.Spec.Containers[0]
+			Expect(container.Args).To(ContainElement("--secure-port=7443"))
+			Expect(container.Args).NotTo(ContainElement("--secure-port=6443"))
+			Expect(container.Ports[0].ContainerPort).To(Equal(int32(7443)))
+			Expect(container.Ports[1].ContainerPort).To(Equal(int32(8080)))
+			Expect(container.LivenessProbe.HTTPGet.Port.IntValue()).To(Equal(7443))
+			Expect(container.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(7443))
+			Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(7443))
+			Expect(service.Spec.Ports[1].TargetPort.IntValue()).To(Equal(8080))
+		})
+	})
+
+	Context("When writing the audit log to a volume", func() {
+		It("Should mount the volume at the path of the live Deployment", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			_, deploy, err := transformManifest()
+			Expect(err).To(BeNil())
+			Expect(transform.AuditLogMountPath(deploy)).To(BeEmpty())
+
+			auditLogTransforms := func(path string) []mf.Transformer {
+				return []mf.Transformer{
+					transform.EnsureAuditLogMount("audit-log", path, scheme.Scheme),
+					transform.ReplaceAuditConfig(path+"/audit-log", "logpath", scheme.Scheme, logr.Discard()),
+				}
+			}
+			_, live, err := transformManifest(auditLogTransforms("/var/audit-policy/log-2024.01.01-00:00")...)
+			Expect(err).To(BeNil())
+			Expect(transform.AuditLogMountPath(live)).To(Equal("/var/audit-policy/log-2024.01.01-00:00"))
+
+			_, deploy, err = transformManifest(auditLogTransforms(transform.AuditLogMountPath(live))...)
+			Expect(err).To(BeNil())
+			Expect(deploy.Spec.Template.Spec.Containers[0].Args).To(Equal(live.Spec.Template.Spec.Containers[0].Args))
+			Expect(deploy.Spec.Template.Spec.Containers[0].VolumeMounts).To(Equal(live.Spec.Template.Spec.Containers[0].VolumeMounts))
+		})
+	})
+})
+
+var _ = Describe("Transforming the images of a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-operator
+  template:
+    metadata:
+      labels:
+        app: keda-operator
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.36
+      containers:
+      - name: keda-operator
+        image: ghcr.io/kedacore/keda:2.17.0
+        imagePullPolicy: Always
+      - name: sidecar
+        image: example/sidecar@sha256:0123456789abcdef
+`
+	transformDeployment := func(transforms ...mf.Transformer) *appsv1.Deployment {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		Expect(err).To(BeNil())
+
+		deploy := &appsv1.Deployment{}
+		Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+		return deploy
+	}
+
+	Context("When rewriting the image registry", func() {
+		It("Should replace the registry of every image with the mirror", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			deploy := transformDeployment(transform.ReplaceImageRegistry("mirror.example.com:5000/cache/", scheme.Scheme))
+			podSpec := deploy.Spec.Template.Spec
+			Expect(podSpec.InitContainers[0].Image).To(Equal("mirror.example.com:5000/cache/library/busybox:1.36"))
+			Expect(podSpec.Containers[0].Image).To(Equal("mirror.example.com:5000/cache/kedacore/keda:2.17.0"))
+			Expect(podSpec.Containers[1].Image).To(Equal("mirror.example.com:5000/cache/example/sidecar@sha256:0123456789abcdef"))
+		})
+	})
+
+	Context("When setting the image, pull policy and pull secrets", func() {
+		It("Should only change the container of the component", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			secrets := []corev1.LocalObjectReference{{Name: "registry-credentials"}}
+			deploy := transformDeployment(
+				transform.ReplaceKedaOperatorImage("registry.example.com/keda:custom", scheme.Scheme),
+				transform.ReplaceKedaOperatorImagePullPolicy(corev1.PullIfNotPresent, scheme.Scheme),
+				transform.ReplaceImagePullSecrets(secrets, scheme.Scheme),
+			)
+			podSpec := deploy.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Image).To(Equal("registry.example.com/keda:custom"))
+			Expect(podSpec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+			Expect(podSpec.Containers[1].Image).To(Equal("example/sidecar@sha256:0123456789abcdef"))
+			Expect(podSpec.ImagePullSecrets).To(Equal(secrets))
+		})
+	})
+})
+
+var _ = Describe("Adding volumes and containers to a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-operator
+  template:
+    metadata:
+      labels:
+        app: keda-operator
+    spec:
+      containers:
+      - name: keda-operator
+        image: ghcr.io/kedacore/keda:2.17.0
+        volumeMounts:
+        - mountPath: /certs
+          name: certificates
+          readOnly: true
+      volumes:
+      - name: certificates
+        secret:
+          secretName: kedaorg-certs
+`
+	transformDeployment := func(transforms ...mf.Transformer) *appsv1.Deployment {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		Expect(err).To(BeNil())
+
+		deploy := &appsv1.Deployment{}
+		Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+		return deploy
+	}
+
+	Context("When adding volumes, volume mounts, init containers and sidecars", func() {
+		It("Should add them without replacing the items of the component", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			volumes := []corev1.Volume{
+				{Name: "certificates", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "gcp-credentials", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "gcp"}}},
+			}
+			volumeMounts := []corev1.VolumeMount{
+				{Name: "gcp-credentials", MountPath: "/certs"},
+				{Name: "gcp-credentials", MountPath: "/var/secrets/gcp"},
+			}
+			initContainers := []corev1.Container{{Name: "init-config", Image: "busybox"}}
+			sidecars := []corev1.Container{
+				{Name: "keda-operator", Image: "busybox"},
+				{Name: "log-shipper", Image: "fluent/fluent-bit"},
+			}
+
+			deploy := transformDeployment(
+				transform.AddVolumes(volumes, scheme.Scheme, logr.Discard()),
+				transform.AddKedaOperatorVolumeMounts(volumeMounts, scheme.Scheme, logr.Discard()),
+				transform.AddInitContainers(initContainers, scheme.Scheme, logr.Discard()),
+				transform.AddSidecarContainers(sidecars, scheme.Scheme, logr.Discard()),
+			)
+
+			podSpec := deploy.Spec.Template.Spec
+			Expect(podSpec.Volumes).To(HaveLen(2))
+			Expect(podSpec.Volumes[0].Secret.SecretName).To(Equal("kedaorg-certs"))
+			Expect(podSpec.Volumes[1].Name).To(Equal("gcp-credentials"))
+
+			Expect(podSpec.Containers).To(HaveLen(2))
+			Expect(podSpec.Containers[0].Image).To(Equal("ghcr.io/kedacore/keda:2.17.0"))
+			Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "certificates", MountPath: "/certs", ReadOnly: true},
+				{Name: "gcp-credentials", MountPath: "/var/secrets/gcp"},
+			}))
+			Expect(podSpec.Containers[1].Name).To(Equal("log-shipper"))
+			Expect(podSpec.InitContainers).To(Equal(initContainers))
+		})
+
+		It("Should be idempotent", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			volumes := []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+			deploy := transformDeployment(
+				transform.AddVolumes(volumes, scheme.Scheme, logr.Discard()),
+				transform.AddVolumes(volumes, scheme.Scheme, logr.Discard()),
+			)
+			Expect(deploy.Spec.Template.Spec.Volumes).To(HaveLen(2))
+		})
+	})
+})
+
+var _ = Describe("Transforming the security context and probes of a Deployment", func() {
+	yamlData := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: keda-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      app: keda-operator
+  template:
+    metadata:
+      labels:
+        app: keda-operator
+    spec:
+      securityContext:
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - name: keda-operator
+        image: ghcr.io/kedacore/keda:2.17.0
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8081
+          initialDelaySeconds: 25
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: 8081
+          initialDelaySeconds: 20
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop:
+            - ALL
+          readOnlyRootFilesystem: true
+`
+	transformDeployment := func(transforms ...mf.Transformer) *appsv1.Deployment {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		Expect(err).To(BeNil())
+
+		deploy := &appsv1.Deployment{}
+		Expect(scheme.Scheme.Convert(&newManifest.Resources()[0], deploy, nil)).To(Succeed())
+		return deploy
+	}
+
+	Context("When overriding the pod and container security context", func() {
+		It("Should replace only the fields which are set", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			runAsUser, fsGroup, readOnly := int64(1000), int64(2000), false
+			localhostProfile := "profiles/keda.json"
+			deploy := transformDeployment(
+				transform.ReplacePodSecurityContext(&corev1.PodSecurityContext{RunAsUser: &runAsUser, FSGroup: &fsGroup}, scheme.Scheme),
+				transform.ReplaceKedaOperatorSecurityContext(&corev1.SecurityContext{
+					ReadOnlyRootFilesystem: &readOnly,
+					SeccompProfile:         &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+				}, scheme.Scheme),
+			)
+
+			podSecurityContext := deploy.Spec.Template.Spec.SecurityContext
+			Expect(*podSecurityContext.RunAsNonRoot).To(BeTrue())
+			Expect(*podSecurityContext.RunAsUser).To(Equal(runAsUser))
+			Expect(*podSecurityContext.FSGroup).To(Equal(fsGroup))
+			Expect(podSecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+
+			securityContext := deploy.Spec.Template.Spec.Containers[0].SecurityContext
+			Expect(*securityContext.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(securityContext.Capabilities.Drop).To(Equal([]corev1.Capability{"ALL"}))
+			Expect(*securityContext.ReadOnlyRootFilesystem).To(BeFalse())
+			Expect(*securityContext.SeccompProfile.LocalhostProfile).To(Equal(localhostProfile))
+		})
+	})
+
+	Context("When overriding the probes", func() {
+		It("Should keep the default handler", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			deploy := transformDeployment(
+				transform.ReplaceKedaOperatorProbes(&corev1.Probe{InitialDelaySeconds: 60, FailureThreshold: 5}, nil, scheme.Scheme),
+			)
+
+			container := deploy.Spec.Template.Spec.Containers[0]
+			Expect(container.LivenessProbe.HTTPGet.Path).To(Equal("/healthz"))
+			Expect(container.LivenessProbe.InitialDelaySeconds).To(Equal(int32(60)))
+			Expect(container.LivenessProbe.FailureThreshold).To(Equal(int32(5)))
+			Expect(container.ReadinessProbe.InitialDelaySeconds).To(Equal(int32(20)))
+		})
+
+		It("Should replace the handler when one is set", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			handler := corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8081)}}
+			deploy := transformDeployment(
+				transform.ReplaceKedaOperatorProbes(nil, &corev1.Probe{ProbeHandler: handler}, scheme.Scheme),
+			)
+
+			readinessProbe := deploy.Spec.Template.Spec.Containers[0].ReadinessProbe
+			Expect(readinessProbe.HTTPGet).To(BeNil())
+			Expect(readinessProbe.TCPSocket.Port.IntValue()).To(Equal(8081))
+			Expect(readinessProbe.InitialDelaySeconds).To(Equal(int32(20)))
+		})
+	})
+})
+
+var _ = Describe("Transforming monitoring resources", func() {
+	yamlData := `---
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: keda-operator
+  namespace: keda
+  labels:
+    app.kubernetes.io/name: keda-operator
+spec:
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: keda-operator
+  endpoints:
+    - port: metrics
+      interval: 60s
+---
+apiVersion: monitoring.coreos.com/v1
+kind: PodMonitor
+metadata:
+  name: keda-olm-operator
+  namespace: keda
+spec:
+  selector:
+    matchLabels:
+      name: keda-olm-operator
+  podMetricsEndpoints:
+    - targetPort: 8080
+      path: /metrics
+      interval: 60s
+`
+	transformMonitors := func(transforms ...mf.Transformer) []unstructured.Unstructured {
+		manifest, err := mf.ManifestFrom(mf.Reader(strings.NewReader(yamlData)))
+		Expect(err).To(BeNil())
+
+		newManifest, err := manifest.Transform(transforms...)
+		Expect(err).To(BeNil())
+		return newManifest.Resources()
+	}
+
+	Context("When adding labels to the monitors", func() {
+		It("Should keep the labels of the manifest", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			monitors := transformMonitors(transform.AddMonitorLabels(map[string]string{
+				"release":                "prometheus",
+				"app.kubernetes.io/name": "other",
+			}))
+			Expect(monitors[0].GetLabels()).To(Equal(map[string]string{
+				"app.kubernetes.io/name": "keda-operator",
+				"release":                "prometheus",
+			}))
+			Expect(monitors[1].GetLabels()).To(HaveKeyWithValue("release", "prometheus"))
+		})
+	})
+
+	Context("When adding labels to all resources", func() {
+		It("Should override the labels of the manifest", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			resources := transformMonitors(transform.AddLabels(map[string]string{
+				"grafana_dashboard":      "1",
+				"app.kubernetes.io/name": "other",
+			}))
+			Expect(resources[0].GetLabels()).To(Equal(map[string]string{
+				"app.kubernetes.io/name": "other",
+				"grafana_dashboard":      "1",
+			}))
+			Expect(resources[1].GetLabels()).To(HaveKeyWithValue("grafana_dashboard", "1"))
+		})
+	})
+
+	Context("When replacing the scrape settings of the endpoints", func() {
+		It("Should set them on ServiceMonitors and PodMonitors", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			relabelings := []map[string]interface{}{{"action": "hashmod", "sourceLabels": []interface{}{"__address__"}, "targetLabel": "shard", "modulus": int64(4)}}
+			monitors := transformMonitors(transform.ReplaceMonitorEndpoints("30s", "10s", relabelings))
+
+			for _, monitor := range monitors {
+				field := "endpoints"
+				if monitor.GetKind() == "PodMonitor" {
+					field = "podMetricsEndpoints"
+				}
+				endpoints, found, err := unstructured.NestedSlice(monitor.Object, "spec", field)
+				Expect(err).To(BeNil())
+				Expect(found).To(BeTrue())
+				endpoint := endpoints[0].(map[string]interface{})
+				Expect(endpoint["interval"]).To(Equal("30s"))
+				Expect(endpoint["scrapeTimeout"]).To(Equal("10s"))
+				Expect(endpoint["relabelings"]).To(HaveLen(1))
+			}
+		})
+
+		It("Should keep the interval of the manifest when it is not set", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			monitors := transformMonitors(transform.ReplaceMonitorEndpoints("", "10s", nil))
+			endpoints, _, err := unstructured.NestedSlice(monitors[0].Object, "spec", "endpoints")
+			Expect(err).To(BeNil())
+			Expect(endpoints[0].(map[string]interface{})["interval"]).To(Equal("60s"))
+			Expect(endpoints[0].(map[string]interface{})).NotTo(HaveKey("relabelings"))
+		})
+	})
+
+	Context("When setting the authorization of the scrapes", func() {
+		It("Should scrape all endpoints over HTTPS with the bearer token", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			tlsConfig := map[string]interface{}{"ca": map[string]interface{}{"configMap": map[string]interface{}{"name": "prometheus-ca", "key": "ca.crt"}}}
+			bearerTokenSecret := map[string]interface{}{"name": "prometheus-token", "key": "token"}
+			monitors := transformMonitors(transform.ReplaceMonitorAuthorization(tlsConfig, bearerTokenSecret))
+
+			for _, monitor := range monitors {
+				field := "endpoints"
+				if monitor.GetKind() == "PodMonitor" {
+					field = "podMetricsEndpoints"
+				}
+				endpoints, found, err := unstructured.NestedSlice(monitor.Object, "spec", field)
+				Expect(err).To(BeNil())
+				Expect(found).To(BeTrue())
+				endpoint := endpoints[0].(map[string]interface{})
+				Expect(endpoint["scheme"]).To(Equal("https"))
+				Expect(endpoint["tlsConfig"]).To(Equal(tlsConfig))
+				Expect(endpoint["bearerTokenSecret"]).To(Equal(bearerTokenSecret))
+			}
+		})
+
+		It("Should keep the scheme of the manifest without a TLS configuration", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			monitors := transformMonitors(transform.ReplaceMonitorAuthorization(nil, map[string]interface{}{"name": "prometheus-token", "key": "token"}))
+			endpoints, _, err := unstructured.NestedSlice(monitors[0].Object, "spec", "endpoints")
+			Expect(err).To(BeNil())
+			Expect(endpoints[0].(map[string]interface{})).NotTo(HaveKey("scheme"))
+			Expect(endpoints[0].(map[string]interface{})).NotTo(HaveKey("tlsConfig"))
+			Expect(endpoints[0].(map[string]interface{})).To(HaveKey("bearerTokenSecret"))
+		})
+	})
+})
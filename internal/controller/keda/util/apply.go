@@ -0,0 +1,70 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda-olm-operator/resources"
+)
+
+const (
+	// FieldManager is the server-side apply field manager owning the fields the operator renders
+	FieldManager = "keda-olm-operator"
+
+	// csaFieldManager is the field manager the resources were updated with while they were
+	// applied through manifestival, it is derived from the name of the operator binary
+	csaFieldManager = "manager"
+)
+
+// ApplyManifest applies the rendered resources with server-side apply. Ownership is forced only for the
+// fields present in the manifest, fields set by other controllers (e.g. resources adjusted by a VPA or
+// sidecars injected by a service mesh) are left untouched.
+func ApplyManifest(ctx context.Context, cl client.Client, manifest mf.Manifest) error {
+	for _, resource := range manifest.Resources() {
+		resource := resource
+		if err := migrateFromClientSideApply(ctx, cl, &resource); err != nil {
+			return fmt.Errorf("unable to migrate %s %s to server-side apply: %w", resource.GetKind(), resource.GetName(), err)
+		}
+
+		resource.SetManagedFields(nil)
+		resource.SetResourceVersion("")
+		if err := cl.Patch(ctx, &resource, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("unable to apply %s %s: %w", resource.GetKind(), resource.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// migrateFromClientSideApply strips the last-applied-configuration annotation manifestival used to track
+// the applied resources and hands the fields it owned over to FieldManager, so that fields the operator
+// no longer renders are removed by the next server-side apply
+func migrateFromClientSideApply(ctx context.Context, cl client.Client, desired *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if _, found := existing.GetAnnotations()[resources.LastConfigID]; !found {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(existing.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if err := csaupgrade.UpgradeManagedFields(existing, sets.New(csaFieldManager), FieldManager); err != nil {
+		return err
+	}
+	annotations := existing.GetAnnotations()
+	delete(annotations, resources.LastConfigID)
+	existing.SetAnnotations(annotations)
+	return cl.Patch(ctx, existing, patch)
+}
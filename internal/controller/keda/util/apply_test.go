@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"context"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+	"github.com/kedacore/keda-olm-operator/resources"
+)
+
+var _ = Describe("Applying a manifest with server-side apply", func() {
+	const (
+		// the field manager manifestival updated the resources with
+		csaFieldManager = "manager"
+		// a field manager of another controller, e.g. a VPA adjusting the resources of the containers
+		otherFieldManager = "vpa-recommender"
+	)
+
+	var (
+		ctx     = context.Background()
+		testEnv *envtest.Environment
+		cl      client.Client
+	)
+
+	BeforeEach(func() {
+		if testType != "functionality" {
+			Skip("test.type isn't 'functionality'")
+		}
+		testEnv = &envtest.Environment{}
+		cfg, err := testEnv.Start()
+		Expect(err).To(BeNil())
+		cl, err = client.New(cfg, client.Options{})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		if testEnv != nil {
+			Expect(testEnv.Stop()).To(Succeed())
+			testEnv = nil
+		}
+	})
+
+	It("Should migrate a resource applied by manifestival to the operator field manager", func() {
+		key := types.NamespacedName{Name: "keda-operator", Namespace: "default"}
+		labels := map[string]string{"app": "keda-operator"}
+		newDeployment := func(env []corev1.EnvVar) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Labels: labels},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{
+							Name:  "keda-operator",
+							Image: "ghcr.io/kedacore/keda:main",
+							Env:   env,
+						}}},
+					},
+				},
+			}
+		}
+
+		By("creating the Deployment the way manifestival did")
+		existing := newDeployment([]corev1.EnvVar{{Name: "KEDA_HTTP_DEFAULT_TIMEOUT", Value: "3000"}})
+		existing.Annotations = map[string]string{resources.LastConfigID: `{"kind":"Deployment"}`}
+		Expect(cl.Create(ctx, existing, client.FieldOwner(csaFieldManager))).To(Succeed())
+
+		By("setting the resources of the container by another controller")
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.Spec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")}
+		Expect(cl.Patch(ctx, existing, patch, client.FieldOwner(otherFieldManager))).To(Succeed())
+
+		By("applying the manifest without the env variable")
+		desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newDeployment(nil))
+		Expect(err).To(BeNil())
+		manifest, err := mf.ManifestFrom(mf.Slice{unstructured.Unstructured{Object: desired}})
+		Expect(err).To(BeNil())
+		Expect(util.ApplyManifest(ctx, cl, manifest)).To(Succeed())
+
+		applied := &appsv1.Deployment{}
+		Expect(cl.Get(ctx, key, applied)).To(Succeed())
+		Expect(applied.Annotations).NotTo(HaveKey(resources.LastConfigID))
+
+		var managers []string
+		for _, entry := range applied.ManagedFields {
+			managers = append(managers, entry.Manager+"/"+string(entry.Operation))
+		}
+		Expect(managers).To(ContainElement(util.FieldManager + "/" + string(metav1.ManagedFieldsOperationApply)))
+		Expect(managers).To(ContainElement(otherFieldManager + "/" + string(metav1.ManagedFieldsOperationUpdate)))
+		for _, manager := range managers {
+			Expect(strings.HasPrefix(manager, csaFieldManager+"/")).To(BeFalse(), "fields are still owned by %s", manager)
+		}
+
+		container := applied.Spec.Template.Spec.Containers[0]
+		Expect(container.Env).To(BeEmpty())
+		Expect(container.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceCPU, resource.MustParse("250m")))
+	})
+})
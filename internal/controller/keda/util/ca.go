@@ -0,0 +1,23 @@
+package util
+
+import (
+	"slices"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// CAReferences returns the sorted names of the ConfigMaps and Secrets referenced by CA sources
+func CAReferences(sources []kedav1alpha1.CASource) (configMaps []string, secrets []string) {
+	for _, source := range sources {
+		if source.ConfigMap != "" {
+			configMaps = append(configMaps, source.ConfigMap)
+		}
+		if source.Secret != "" {
+			secrets = append(secrets, source.Secret)
+		}
+	}
+
+	slices.Sort(configMaps)
+	slices.Sort(secrets)
+	return slices.Compact(configMaps), slices.Compact(secrets)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Collecting the objects referenced by CA sources", func() {
+	It("Should return the sorted and unique names of ConfigMaps and Secrets", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		configMaps, secrets := util.CAReferences([]kedav1alpha1.CASource{
+			{ConfigMap: "service-ca"},
+			{Secret: "vault-ca"},
+			{ConfigMap: "corporate-ca"},
+			{ConfigMap: "service-ca"},
+		})
+		Expect(configMaps).To(Equal([]string{"corporate-ca", "service-ca"}))
+		Expect(secrets).To(Equal([]string{"vault-ca"}))
+	})
+})
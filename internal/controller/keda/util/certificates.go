@@ -0,0 +1,65 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// CertificateExpiration returns the expiration time of the first certificate in PEM encoded data
+func CertificateExpiration(data []byte) (time.Time, error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return cert.NotAfter, nil
+	}
+	return time.Time{}, fmt.Errorf("no PEM encoded certificate found")
+}
+
+// VerifyCertificate verifies that the PEM encoded certificate matches its key and is issued by the PEM encoded
+// CA bundle for dnsName, or for no particular name when it is empty, and for each of usages
+func VerifyCertificate(certPEM, keyPEM, caPEM []byte, dnsName string, usages ...x509.ExtKeyUsage) error {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("no PEM encoded certificate found in the CA bundle")
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range pair.Certificate[1:] {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		intermediates.AddCert(cert)
+	}
+
+	if len(usages) == 0 {
+		usages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	// a chain is accepted if it allows any of the usages, so each one is verified on its own
+	for _, usage := range usages {
+		if _, err := leaf.Verify(x509.VerifyOptions{
+			DNSName:       dnsName,
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{usage},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
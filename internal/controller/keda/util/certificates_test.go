@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Reading the expiration of a certificate", func() {
+	newCertificate := func(notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).To(BeNil())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "keda-operator"},
+			NotBefore:    notAfter.Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).To(BeNil())
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	It("Should return the expiration of the first certificate", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second).UTC()
+		data := append(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}), newCertificate(notAfter)...)
+		data = append(data, newCertificate(notAfter.Add(time.Hour))...)
+
+		expiration, err := util.CertificateExpiration(data)
+		Expect(err).To(BeNil())
+		Expect(expiration).To(Equal(notAfter))
+	})
+
+	It("Should fail without a certificate", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		_, err := util.CertificateExpiration([]byte("not a certificate"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Verifying a certificate", func() {
+	type issued struct {
+		cert, key []byte
+		template  *x509.Certificate
+		signer    *ecdsa.PrivateKey
+	}
+	issue := func(template *x509.Certificate, parent *issued) issued {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).To(BeNil())
+		parentTemplate, signer := template, key
+		if parent != nil {
+			parentTemplate, signer = parent.template, parent.signer
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parentTemplate, &key.PublicKey, signer)
+		Expect(err).To(BeNil())
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).To(BeNil())
+		return issued{
+			cert:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			key:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+			template: template,
+			signer:   key,
+		}
+	}
+	newCA := func() issued {
+		return issue(&x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "KEDA"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}, nil)
+	}
+	newServing := func(ca issued, usages ...x509.ExtKeyUsage) issued {
+		return issue(&x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "keda-operator"},
+			DNSNames:     []string{"keda-operator.keda.svc.cluster.local"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  usages,
+		}, &ca)
+	}
+
+	It("Should accept a certificate issued by the CA for the name and usages", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		ca := newCA()
+		serving := newServing(ca, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+		Expect(util.VerifyCertificate(serving.cert, serving.key, ca.cert, "keda-operator.keda.svc.cluster.local",
+			x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)).To(Succeed())
+	})
+
+	It("Should reject a certificate for another name", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		ca := newCA()
+		serving := newServing(ca, x509.ExtKeyUsageServerAuth)
+		Expect(util.VerifyCertificate(serving.cert, serving.key, ca.cert, "keda-metrics-apiserver.keda.svc")).NotTo(Succeed())
+	})
+
+	It("Should reject a certificate without one of the usages", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		ca := newCA()
+		serving := newServing(ca, x509.ExtKeyUsageServerAuth)
+		Expect(util.VerifyCertificate(serving.cert, serving.key, ca.cert, "",
+			x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)).NotTo(Succeed())
+	})
+
+	It("Should reject a certificate issued by another CA", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		serving := newServing(newCA(), x509.ExtKeyUsageServerAuth)
+		Expect(util.VerifyCertificate(serving.cert, serving.key, newCA().cert, "keda-operator.keda.svc.cluster.local")).NotTo(Succeed())
+	})
+
+	It("Should reject a key not matching the certificate", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		ca := newCA()
+		serving := newServing(ca, x509.ExtKeyUsageServerAuth)
+		Expect(util.VerifyCertificate(serving.cert, ca.key, ca.cert, "keda-operator.keda.svc.cluster.local")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Calculating checksums of ConfigMap and Secret data", func() {
+	configMapData := make(map[string]string)
+	secretData := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		configMapData[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("value-%d", i)
+		secretData[fmt.Sprintf("key-%d", i)] = []byte(fmt.Sprintf("value-%d", i))
+	}
+
+	Context("When calculating the checksum of the same data repeatedly", func() {
+		It("Should always return the same checksum", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			configMapSum := util.CalculateConfigMapDataCheckSum(configMapData)
+			secretSum := util.CalculateSecretedDataCheckSum(secretData)
+			for i := 0; i < 50; i++ {
+				Expect(util.CalculateConfigMapDataCheckSum(configMapData)).To(Equal(configMapSum))
+				Expect(util.CalculateSecretedDataCheckSum(secretData)).To(Equal(secretSum))
+			}
+			Expect(configMapSum).To(Equal(secretSum))
+		})
+	})
+
+	Context("When the data changes", func() {
+		It("Should return a different checksum", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			Expect(util.CalculateConfigMapDataCheckSum(map[string]string{"ca.crt": "old"})).
+				NotTo(Equal(util.CalculateConfigMapDataCheckSum(map[string]string{"ca.crt": "new"})))
+			Expect(util.CalculateSecretedDataCheckSum(map[string][]byte{"tls.crt": []byte("old")})).
+				NotTo(Equal(util.CalculateSecretedDataCheckSum(map[string][]byte{"tls.crt": []byte("new")})))
+		})
+
+		It("Should not mix up keys and values", func() {
+			if testType != "unit" {
+				Skip("test.type isn't 'unit'")
+			}
+
+			Expect(util.CalculateConfigMapDataCheckSum(map[string]string{"ab": "c"})).
+				NotTo(Equal(util.CalculateConfigMapDataCheckSum(map[string]string{"a": "bc"})))
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EnvReferences returns the sorted names of the ConfigMaps and Secrets referenced by env and envFrom
+func EnvReferences(env []corev1.EnvVar, envFrom []corev1.EnvFromSource) (configMaps []string, secrets []string) {
+	for _, envVar := range env {
+		if envVar.ValueFrom == nil {
+			continue
+		}
+		if ref := envVar.ValueFrom.ConfigMapKeyRef; ref != nil && ref.Name != "" {
+			configMaps = append(configMaps, ref.Name)
+		}
+		if ref := envVar.ValueFrom.SecretKeyRef; ref != nil && ref.Name != "" {
+			secrets = append(secrets, ref.Name)
+		}
+	}
+	for _, source := range envFrom {
+		if ref := source.ConfigMapRef; ref != nil && ref.Name != "" {
+			configMaps = append(configMaps, ref.Name)
+		}
+		if ref := source.SecretRef; ref != nil && ref.Name != "" {
+			secrets = append(secrets, ref.Name)
+		}
+	}
+
+	slices.Sort(configMaps)
+	slices.Sort(secrets)
+	return slices.Compact(configMaps), slices.Compact(secrets)
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+)
+
+// RolloutState describes how far a Deployment got in rolling out its current spec
+type RolloutState string
+
+const (
+	RolloutComplete    RolloutState = "Complete"
+	RolloutProgressing RolloutState = "Progressing"
+	RolloutFailed      RolloutState = "Failed"
+)
+
+// GetDeploymentRolloutState checks the Deployment status the same way `kubectl rollout status` does,
+// it returns the state of the rollout together with a human readable message
+func GetDeploymentRolloutState(deploy *appsv1.Deployment) (RolloutState, string) {
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		return RolloutProgressing, fmt.Sprintf("waiting for Deployment %s spec update to be observed", deploy.Name)
+	}
+
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			return RolloutFailed, fmt.Sprintf("Deployment %s exceeded its progress deadline: %s", deploy.Name, cond.Message)
+		}
+	}
+
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+
+	status := deploy.Status
+	if status.UpdatedReplicas < replicas {
+		return RolloutProgressing, fmt.Sprintf("%d out of %d new replicas of Deployment %s have been updated", status.UpdatedReplicas, replicas, deploy.Name)
+	}
+	if status.Replicas > status.UpdatedReplicas {
+		return RolloutProgressing, fmt.Sprintf("%d old replicas of Deployment %s are pending termination", status.Replicas-status.UpdatedReplicas, deploy.Name)
+	}
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return RolloutProgressing, fmt.Sprintf("%d of %d updated replicas of Deployment %s are available", status.AvailableReplicas, status.UpdatedReplicas, deploy.Name)
+	}
+
+	return RolloutComplete, fmt.Sprintf("%d of %d replicas of Deployment %s are available", status.AvailableReplicas, replicas, deploy.Name)
+}
+
+// IsAPIServiceAvailable returns whether the APIService reports the Available condition,
+// the message explains why it is not available
+func IsAPIServiceAvailable(apiService *apiregistrationv1.APIService) (bool, string) {
+	for _, cond := range apiService.Status.Conditions {
+		if cond.Type == apiregistrationv1.Available {
+			if cond.Status == apiregistrationv1.ConditionTrue {
+				return true, fmt.Sprintf("APIService %s is available", apiService.Name)
+			}
+			return false, fmt.Sprintf("APIService %s is not available: %s: %s", apiService.Name, cond.Reason, cond.Message)
+		}
+	}
+	return false, fmt.Sprintf("APIService %s has not reported its availability yet", apiService.Name)
+}
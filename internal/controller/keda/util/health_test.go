@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Checking the rollout state of a Deployment", func() {
+	replicas := int32(2)
+	testData := []struct {
+		context    string
+		generation int64
+		status     appsv1.DeploymentStatus
+		expected   util.RolloutState
+	}{
+		{
+			context:    "When the spec update has not been observed yet",
+			generation: 2,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			expected:   util.RolloutProgressing,
+		},
+		{
+			context:    "When not all replicas have been updated",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2},
+			expected:   util.RolloutProgressing,
+		},
+		{
+			context:    "When old replicas are still terminating",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2},
+			expected:   util.RolloutProgressing,
+		},
+		{
+			context:    "When updated replicas are not available yet",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			expected:   util.RolloutProgressing,
+		},
+		{
+			context:    "When the progress deadline was exceeded",
+			generation: 1,
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				}},
+			},
+			expected: util.RolloutFailed,
+		},
+		{
+			context:    "When all replicas are updated and available",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			expected:   util.RolloutComplete,
+		},
+	}
+	for _, tt := range testData {
+		Context(tt.context, func() {
+			It("Should report the expected rollout state", func() {
+				if testType != "unit" {
+					Skip("test.type isn't 'unit'")
+				}
+				deploy := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "keda-operator", Generation: tt.generation},
+					Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+					Status:     tt.status,
+				}
+				state, msg := util.GetDeploymentRolloutState(deploy)
+				Expect(state).To(Equal(tt.expected))
+				Expect(msg).To(ContainSubstring("keda-operator"))
+			})
+		})
+	}
+})
+
+var _ = Describe("Checking the availability of an APIService", func() {
+	testData := []struct {
+		context    string
+		conditions []apiregistrationv1.APIServiceCondition
+		expected   bool
+	}{
+		{"When no condition is reported", nil, false},
+		{"When the APIService is not available", []apiregistrationv1.APIServiceCondition{
+			{Type: apiregistrationv1.Available, Status: apiregistrationv1.ConditionFalse, Reason: "FailedDiscoveryCheck"},
+		}, false},
+		{"When the APIService is available", []apiregistrationv1.APIServiceCondition{
+			{Type: apiregistrationv1.Available, Status: apiregistrationv1.ConditionTrue},
+		}, true},
+	}
+	for _, tt := range testData {
+		Context(tt.context, func() {
+			It("Should report whether the APIService is available", func() {
+				if testType != "unit" {
+					Skip("test.type isn't 'unit'")
+				}
+				apiService := &apiregistrationv1.APIService{
+					ObjectMeta: metav1.ObjectMeta{Name: "v1beta1.external.metrics.k8s.io"},
+					Status:     apiregistrationv1.APIServiceStatus{Conditions: tt.conditions},
+				}
+				available, _ := util.IsAPIServiceAvailable(apiService)
+				Expect(available).To(Equal(tt.expected))
+			})
+		})
+	}
+})
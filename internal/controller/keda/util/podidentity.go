@@ -0,0 +1,156 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+const (
+	// GCPCredentialsConfigMapName is the ConfigMap holding the credential configuration of GCP Workload Identity Federation
+	GCPCredentialsConfigMapName = "keda-gcp-credentials"
+	// GCPCredentialsKey is the key of the credential configuration in GCPCredentialsConfigMapName
+	GCPCredentialsKey = "credentials.json"
+
+	awsTokenVolume     = "aws-iam-token"
+	awsTokenDir        = "/var/run/secrets/eks.amazonaws.com/serviceaccount"
+	awsDefaultAudience = "sts.amazonaws.com"
+
+	azureTokenVolume          = "azure-identity-token"
+	azureTokenDir             = "/var/run/secrets/azure/tokens"
+	azureAudience             = "api://AzureADTokenExchange"
+	azureDefaultAuthorityHost = "https://login.microsoftonline.com/"
+
+	gcpTokenVolume       = "gcp-identity-token"
+	gcpTokenDir          = "/var/run/secrets/gcp/serviceaccount"
+	gcpCredentialsVolume = "gcp-credentials"
+	gcpCredentialsDir    = "/var/run/secrets/gcp/credentials"
+
+	tokenExpirationSeconds int64 = 3600
+)
+
+// PodIdentity is what the cloud workload identities of a PodIdentitySpec add to KEDA Operator
+type PodIdentity struct {
+	ServiceAccountAnnotations map[string]string
+	Volumes                   []corev1.Volume
+	VolumeMounts              []corev1.VolumeMount
+	Env                       []corev1.EnvVar
+	// GCPCredentials is the credential configuration to store in GCPCredentialsConfigMapName, empty without GCP
+	GCPCredentials string
+}
+
+// PodIdentityFor renders the ServiceAccount annotations, the projected ServiceAccount tokens and the environment
+// variables of the cloud workload identities in spec
+func PodIdentityFor(spec kedav1alpha1.PodIdentitySpec) (PodIdentity, error) {
+	identity := PodIdentity{ServiceAccountAnnotations: map[string]string{}}
+
+	if aws := spec.AWS; aws != nil {
+		audience := aws.Audience
+		if audience == "" {
+			audience = awsDefaultAudience
+		}
+		identity.addToken(awsTokenVolume, awsTokenDir, "token", audience)
+		identity.ServiceAccountAnnotations["eks.amazonaws.com/role-arn"] = aws.RoleARN
+		identity.Env = append(identity.Env,
+			corev1.EnvVar{Name: "AWS_ROLE_ARN", Value: aws.RoleARN},
+			corev1.EnvVar{Name: "AWS_WEB_IDENTITY_TOKEN_FILE", Value: path.Join(awsTokenDir, "token")},
+		)
+		if aws.Region != "" {
+			identity.Env = append(identity.Env, corev1.EnvVar{Name: "AWS_REGION", Value: aws.Region})
+		}
+	}
+
+	if azure := spec.AzureWorkload; azure != nil {
+		authorityHost := azure.AuthorityHost
+		if authorityHost == "" {
+			authorityHost = azureDefaultAuthorityHost
+		}
+		identity.addToken(azureTokenVolume, azureTokenDir, azureTokenVolume, azureAudience)
+		identity.ServiceAccountAnnotations["azure.workload.identity/client-id"] = azure.ClientID
+		identity.ServiceAccountAnnotations["azure.workload.identity/tenant-id"] = azure.TenantID
+		identity.Env = append(identity.Env,
+			corev1.EnvVar{Name: "AZURE_CLIENT_ID", Value: azure.ClientID},
+			corev1.EnvVar{Name: "AZURE_TENANT_ID", Value: azure.TenantID},
+			corev1.EnvVar{Name: "AZURE_FEDERATED_TOKEN_FILE", Value: path.Join(azureTokenDir, azureTokenVolume)},
+			corev1.EnvVar{Name: "AZURE_AUTHORITY_HOST", Value: authorityHost},
+		)
+	}
+
+	if gcp := spec.GCP; gcp != nil {
+		credentials, err := gcpCredentialConfiguration(gcp)
+		if err != nil {
+			return PodIdentity{}, err
+		}
+		identity.GCPCredentials = credentials
+		identity.addToken(gcpTokenVolume, gcpTokenDir, "token", gcp.Audience)
+		identity.Volumes = append(identity.Volumes, corev1.Volume{
+			Name: gcpCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: GCPCredentialsConfigMapName},
+			}},
+		})
+		identity.VolumeMounts = append(identity.VolumeMounts, corev1.VolumeMount{Name: gcpCredentialsVolume, MountPath: gcpCredentialsDir, ReadOnly: true})
+		identity.ServiceAccountAnnotations["iam.gke.io/gcp-service-account"] = gcp.ServiceAccountEmail
+		identity.Env = append(identity.Env, corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: path.Join(gcpCredentialsDir, GCPCredentialsKey)})
+	}
+
+	return identity, nil
+}
+
+// addToken adds a projected ServiceAccount token for audience, mounted read-only to dir/file
+func (p *PodIdentity) addToken(volume, dir, file, audience string) {
+	expiration := tokenExpirationSeconds
+	p.Volumes = append(p.Volumes, corev1.Volume{
+		Name: volume,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          audience,
+				ExpirationSeconds: &expiration,
+				Path:              file,
+			}}},
+		}},
+	})
+	p.VolumeMounts = append(p.VolumeMounts, corev1.VolumeMount{Name: volume, MountPath: dir, ReadOnly: true})
+}
+
+// gcpCredentialConfiguration returns the external account credential configuration exchanging the projected
+// ServiceAccount token for an access token of the GCP service account
+func gcpCredentialConfiguration(spec *kedav1alpha1.GCPPodIdentitySpec) (string, error) {
+	configuration := map[string]interface{}{
+		"type":               "external_account",
+		"audience":           spec.Audience,
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url":          "https://sts.googleapis.com/v1/token",
+		"service_account_impersonation_url": fmt.Sprintf(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", spec.ServiceAccountEmail),
+		"credential_source": map[string]interface{}{
+			"file":   path.Join(gcpTokenDir, "token"),
+			"format": map[string]string{"type": "text"},
+		},
+	}
+	data, err := json.MarshalIndent(configuration, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// PodIdentityProviders returns the KEDA pod identity providers of the ClusterTriggerAuthentications requested in spec,
+// keyed by the name of the ClusterTriggerAuthentication
+func PodIdentityProviders(spec kedav1alpha1.PodIdentitySpec) map[string]string {
+	providers := map[string]string{}
+	if spec.AWS != nil && spec.AWS.ClusterTriggerAuthentication != "" {
+		providers[spec.AWS.ClusterTriggerAuthentication] = "aws"
+	}
+	if spec.AzureWorkload != nil && spec.AzureWorkload.ClusterTriggerAuthentication != "" {
+		providers[spec.AzureWorkload.ClusterTriggerAuthentication] = "azure-workload"
+	}
+	if spec.GCP != nil && spec.GCP.ClusterTriggerAuthentication != "" {
+		providers[spec.GCP.ClusterTriggerAuthentication] = "gcp"
+	}
+	return providers
+}
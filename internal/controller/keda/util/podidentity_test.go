@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Rendering the pod identity of KEDA Operator", func() {
+	It("Should render nothing without a cloud provider", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		identity, err := util.PodIdentityFor(kedav1alpha1.PodIdentitySpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.ServiceAccountAnnotations).To(BeEmpty())
+		Expect(identity.Volumes).To(BeEmpty())
+		Expect(identity.Env).To(BeEmpty())
+		Expect(identity.GCPCredentials).To(BeEmpty())
+	})
+
+	It("Should render the projected token, the annotations and the env of each provider", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		identity, err := util.PodIdentityFor(kedav1alpha1.PodIdentitySpec{
+			AWS: &kedav1alpha1.AWSPodIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/keda-operator", Region: "eu-west-1"},
+			AzureWorkload: &kedav1alpha1.AzureWorkloadPodIdentitySpec{
+				ClientID: "00000000-0000-0000-0000-000000000001",
+				TenantID: "00000000-0000-0000-0000-000000000002",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(identity.ServiceAccountAnnotations).To(Equal(map[string]string{
+			"eks.amazonaws.com/role-arn":        "arn:aws:iam::123456789012:role/keda-operator",
+			"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000001",
+			"azure.workload.identity/tenant-id": "00000000-0000-0000-0000-000000000002",
+		}))
+		Expect(identity.Volumes).To(HaveLen(2))
+		Expect(identity.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience).To(Equal("sts.amazonaws.com"))
+		Expect(identity.Volumes[1].Projected.Sources[0].ServiceAccountToken.Audience).To(Equal("api://AzureADTokenExchange"))
+		Expect(identity.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name: "aws-iam-token", MountPath: "/var/run/secrets/eks.amazonaws.com/serviceaccount", ReadOnly: true,
+		}))
+		Expect(identity.Env).To(ContainElements(
+			corev1.EnvVar{Name: "AWS_ROLE_ARN", Value: "arn:aws:iam::123456789012:role/keda-operator"},
+			corev1.EnvVar{Name: "AWS_WEB_IDENTITY_TOKEN_FILE", Value: "/var/run/secrets/eks.amazonaws.com/serviceaccount/token"},
+			corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"},
+			corev1.EnvVar{Name: "AZURE_FEDERATED_TOKEN_FILE", Value: "/var/run/secrets/azure/tokens/azure-identity-token"},
+			corev1.EnvVar{Name: "AZURE_AUTHORITY_HOST", Value: "https://login.microsoftonline.com/"},
+		))
+	})
+
+	It("Should render the GCP credential configuration", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		audience := "//iam.googleapis.com/projects/123456/locations/global/workloadIdentityPools/pool/providers/provider"
+		identity, err := util.PodIdentityFor(kedav1alpha1.PodIdentitySpec{
+			GCP: &kedav1alpha1.GCPPodIdentitySpec{ServiceAccountEmail: "keda@project.iam.gserviceaccount.com", Audience: audience},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		credentials := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(identity.GCPCredentials), &credentials)).To(Succeed())
+		Expect(credentials).To(HaveKeyWithValue("type", "external_account"))
+		Expect(credentials).To(HaveKeyWithValue("audience", audience))
+		Expect(credentials).To(HaveKeyWithValue("service_account_impersonation_url",
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/keda@project.iam.gserviceaccount.com:generateAccessToken"))
+		Expect(credentials["credential_source"]).To(HaveKeyWithValue("file", "/var/run/secrets/gcp/serviceaccount/token"))
+		Expect(identity.Env).To(ConsistOf(corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/var/run/secrets/gcp/credentials/credentials.json"}))
+		Expect(identity.Volumes).To(HaveLen(2))
+		Expect(identity.Volumes[1].ConfigMap.Name).To(Equal(util.GCPCredentialsConfigMapName))
+	})
+
+	It("Should return the providers of the requested ClusterTriggerAuthentications", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		Expect(util.PodIdentityProviders(kedav1alpha1.PodIdentitySpec{
+			AWS: &kedav1alpha1.AWSPodIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/keda", ClusterTriggerAuthentication: "aws"},
+			GCP: &kedav1alpha1.GCPPodIdentitySpec{ServiceAccountEmail: "keda@project.iam.gserviceaccount.com"},
+		})).To(Equal(map[string]string{"aws": "aws"}))
+	})
+})
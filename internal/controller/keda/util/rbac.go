@@ -0,0 +1,65 @@
+package util
+
+import (
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// clusterScopedResources are the cluster-scoped resources KEDA accesses, they can't be granted by a Role
+var clusterScopedResources = []string{
+	"apiservices",
+	"clustercloudeventsources",
+	"clustertriggerauthentications",
+	"customresourcedefinitions",
+	"mutatingwebhookconfigurations",
+	"namespaces",
+	"nodes",
+	"validatingwebhookconfigurations",
+}
+
+// SplitPolicyRules splits the rules of a ClusterRole into the rules on cluster-scoped resources, which have to stay
+// in the ClusterRole, and the rules on namespaced resources, which can be granted by a Role in each watched namespace.
+// Rules on all resources are namespaced, the reads of CRDs they cover are kept cluster-wide.
+func SplitPolicyRules(rules []rbacv1.PolicyRule) (clusterRules, namespacedRules []rbacv1.PolicyRule) {
+	crdReads := false
+	for _, rule := range rules {
+		if len(rule.NonResourceURLs) > 0 {
+			clusterRules = append(clusterRules, rule)
+			continue
+		}
+
+		var clusterResources, namespacedResources []string
+		for _, resource := range rule.Resources {
+			if slices.Contains(clusterScopedResources, strings.SplitN(resource, "/", 2)[0]) {
+				clusterResources = append(clusterResources, resource)
+			} else {
+				namespacedResources = append(namespacedResources, resource)
+			}
+			if resource == rbacv1.ResourceAll && slices.Contains(rule.Verbs, "get") {
+				crdReads = true
+			}
+		}
+
+		if len(clusterResources) > 0 {
+			clusterRule := *rule.DeepCopy()
+			clusterRule.Resources = clusterResources
+			clusterRules = append(clusterRules, clusterRule)
+		}
+		if len(namespacedResources) > 0 {
+			namespacedRule := *rule.DeepCopy()
+			namespacedRule.Resources = namespacedResources
+			namespacedRules = append(namespacedRules, namespacedRule)
+		}
+	}
+
+	if crdReads {
+		clusterRules = append(clusterRules, rbacv1.PolicyRule{
+			APIGroups: []string{"apiextensions.k8s.io"},
+			Resources: []string{"customresourcedefinitions"},
+			Verbs:     []string{"get"},
+		})
+	}
+	return clusterRules, namespacedRules
+}
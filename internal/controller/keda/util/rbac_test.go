@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Splitting the rules of a ClusterRole", func() {
+	It("Should keep only the rules on cluster-scoped resources cluster-wide", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		clusterRules, namespacedRules := util.SplitPolicyRules([]rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"get", "list", "watch"}},
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}},
+			{APIGroups: []string{"*"}, Resources: []string{"*/scale"}, Verbs: []string{"get", "update"}},
+			{APIGroups: []string{"apiregistration.k8s.io"}, Resources: []string{"apiservices"}, Verbs: []string{"get", "patch"}},
+			{APIGroups: []string{"keda.sh"}, Resources: []string{"clustertriggerauthentications/status", "scaledobjects"}, Verbs: []string{"update"}},
+		})
+
+		Expect(clusterRules).To(Equal([]rbacv1.PolicyRule{
+			{APIGroups: []string{"apiregistration.k8s.io"}, Resources: []string{"apiservices"}, Verbs: []string{"get", "patch"}},
+			{APIGroups: []string{"keda.sh"}, Resources: []string{"clustertriggerauthentications/status"}, Verbs: []string{"update"}},
+			{APIGroups: []string{"apiextensions.k8s.io"}, Resources: []string{"customresourcedefinitions"}, Verbs: []string{"get"}},
+		}))
+		Expect(namespacedRules).To(Equal([]rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"get", "list", "watch"}},
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}},
+			{APIGroups: []string{"*"}, Resources: []string{"*/scale"}, Verbs: []string{"get", "update"}},
+			{APIGroups: []string{"keda.sh"}, Resources: []string{"scaledobjects"}, Verbs: []string{"update"}},
+		}))
+	})
+})
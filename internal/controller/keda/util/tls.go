@@ -0,0 +1,79 @@
+package util
+
+import (
+	"crypto/tls"
+	"slices"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// openSSLToIANACipherSuites maps the OpenSSL names used by the TLS security profiles to the IANA names
+var openSSLToIANACipherSuites = map[string]string{
+	"ECDHE-ECDSA-CHACHA20-POLY1305": "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-CHACHA20-POLY1305":   "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-ECDSA-AES128-GCM-SHA256": "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-RSA-AES128-GCM-SHA256":   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-ECDSA-AES256-GCM-SHA384": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384":   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-ECDSA-AES128-SHA256":     "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-RSA-AES128-SHA256":       "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-ECDSA-AES128-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-RSA-AES128-SHA":          "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-ECDSA-AES256-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-RSA-AES256-SHA":          "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+	"AES128-GCM-SHA256":             "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"AES256-GCM-SHA384":             "TLS_RSA_WITH_AES_256_GCM_SHA384",
+	"AES128-SHA256":                 "TLS_RSA_WITH_AES_128_CBC_SHA256",
+	"AES128-SHA":                    "TLS_RSA_WITH_AES_128_CBC_SHA",
+	"AES256-SHA":                    "TLS_RSA_WITH_AES_256_CBC_SHA",
+	"DES-CBC3-SHA":                  "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+}
+
+// ResolveTLSProfile returns the minimum TLS version and the ciphers of a TLS security profile, an unset
+// or incomplete profile is the Intermediate one, which is the default of the OpenShift APIServer as well
+func ResolveTLSProfile(profile *configv1.TLSSecurityProfile) configv1.TLSProfileSpec {
+	intermediate := *configv1.TLSProfiles[configv1.TLSProfileIntermediateType]
+	if profile == nil {
+		return intermediate
+	}
+	if profile.Type == configv1.TLSProfileCustomType {
+		if profile.Custom == nil || profile.Custom.MinTLSVersion == "" {
+			return intermediate
+		}
+		return profile.Custom.TLSProfileSpec
+	}
+	if spec, found := configv1.TLSProfiles[profile.Type]; found {
+		return *spec
+	}
+	return intermediate
+}
+
+// IANACipherSuites converts the ciphers of a TLS security profile to the IANA names used by Go and the Kubernetes
+// API server. TLS 1.3 cipher suites can't be configured in Go, they are dropped together with the ones Go doesn't implement
+func IANACipherSuites(ciphers []string) []string {
+	var supported []string
+	for _, suites := range [][]*tls.CipherSuite{tls.CipherSuites(), tls.InsecureCipherSuites()} {
+		for _, suite := range suites {
+			if !slices.Equal(suite.SupportedVersions, []uint16{tls.VersionTLS13}) {
+				supported = append(supported, suite.Name)
+			}
+		}
+	}
+
+	var result []string
+	for _, cipher := range ciphers {
+		if name, found := openSSLToIANACipherSuites[cipher]; found {
+			cipher = name
+		}
+		if slices.Contains(supported, cipher) && !slices.Contains(result, cipher) {
+			result = append(result, cipher)
+		}
+	}
+	return result
+}
+
+// KedaMinTLSVersion converts a TLS protocol version of a profile, e.g. 'VersionTLS12', to the value KEDA expects, e.g. 'TLS12'
+func KedaMinTLSVersion(version configv1.TLSProtocolVersion) string {
+	return strings.TrimPrefix(string(version), "Version")
+}
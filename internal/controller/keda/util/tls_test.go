@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+var _ = Describe("Resolving TLS security profiles", func() {
+	It("Should default to the Intermediate profile", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		Expect(util.ResolveTLSProfile(nil).MinTLSVersion).To(Equal(configv1.VersionTLS12))
+		Expect(util.ResolveTLSProfile(&configv1.TLSSecurityProfile{Type: configv1.TLSProfileCustomType}).MinTLSVersion).
+			To(Equal(configv1.VersionTLS12))
+		Expect(util.ResolveTLSProfile(&configv1.TLSSecurityProfile{
+			Type:   configv1.TLSProfileCustomType,
+			Custom: &configv1.CustomTLSProfile{TLSProfileSpec: configv1.TLSProfileSpec{Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"}}},
+		}).MinTLSVersion).To(Equal(configv1.VersionTLS12))
+	})
+
+	It("Should return the predefined and custom profiles", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		Expect(util.ResolveTLSProfile(&configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType}).MinTLSVersion).
+			To(Equal(configv1.VersionTLS13))
+		custom := util.ResolveTLSProfile(&configv1.TLSSecurityProfile{
+			Type: configv1.TLSProfileCustomType,
+			Custom: &configv1.CustomTLSProfile{TLSProfileSpec: configv1.TLSProfileSpec{
+				Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				MinTLSVersion: configv1.VersionTLS11,
+			}},
+		})
+		Expect(custom.Ciphers).To(Equal([]string{"ECDHE-RSA-AES128-GCM-SHA256"}))
+		Expect(util.KedaMinTLSVersion(custom.MinTLSVersion)).To(Equal("TLS11"))
+	})
+
+	It("Should convert the ciphers to the IANA cipher suites supported by Go", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+
+		Expect(util.IANACipherSuites([]string{
+			"TLS_AES_128_GCM_SHA256",
+			"ECDHE-ECDSA-AES128-GCM-SHA256",
+			"DHE-RSA-AES128-GCM-SHA256",
+			"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			"ECDHE-ECDSA-AES128-GCM-SHA256",
+		})).To(Equal([]string{
+			"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		}))
+		Expect(util.IANACipherSuites(configv1.TLSProfiles[configv1.TLSProfileModernType].Ciphers)).To(BeEmpty())
+	})
+})
@@ -4,11 +4,13 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"unicode"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -18,50 +20,38 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
 )
 
-const (
-	metricsServerPodLabelKey   = "app"
-	metricsServerPodLabelValue = "keda-metrics-apiserver"
-)
-
+// CalculateConfigMapDataCheckSum returns a checksum of the ConfigMap data which doesn't depend on the map iteration order
 func CalculateConfigMapDataCheckSum(m map[string]string) string {
-	var data string
-	for k, v := range m {
-		data = data + k + v
+	hash := md5.New()
+	for _, k := range sortedKeys(m) {
+		writeChecksumEntry(hash, k, []byte(m[k]))
 	}
-	return fmt.Sprintf("%x", md5.Sum([]byte(data)))
+	return fmt.Sprintf("%x", hash.Sum(nil))
 }
 
+// CalculateSecretedDataCheckSum returns a checksum of the Secret data which doesn't depend on the map iteration order
 func CalculateSecretedDataCheckSum(m map[string][]byte) string {
-	var data string
-	for k, v := range m {
-		data = data + k + string(v)
+	hash := md5.New()
+	for _, k := range sortedKeys(m) {
+		writeChecksumEntry(hash, k, m[k])
 	}
-	return fmt.Sprintf("%x", md5.Sum([]byte(data)))
+	return fmt.Sprintf("%x", hash.Sum(nil))
 }
 
-func DeleteMetricsServerPod(ctx context.Context, metricsServerNamespace string, logger logr.Logger, cl client.Client) error {
-	selector := make(map[string]string)
-	selector[metricsServerPodLabelKey] = metricsServerPodLabelValue
-
-	podList := &corev1.PodList{}
-	opts := []client.ListOption{
-		client.InNamespace(metricsServerNamespace),
-		client.MatchingLabels(selector),
-	}
-	err := cl.List(ctx, podList, opts...)
-	if err != nil {
-		return err
-	}
-	if len(podList.Items) == 0 {
-		logger.Info("KEDA Metrics Server is not running -> no need to restart it")
-		return nil
-	} else if len(podList.Items) != 1 {
-		return fmt.Errorf("exactly one Pod object should match label %s", selector)
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	pod := &podList.Items[0]
-	// restart Metrics Server Pod
-	return cl.Delete(ctx, pod)
+// writeChecksumEntry writes the length of the key and value along with them,
+// so that e.g. {"ab": "c"} and {"a": "bc"} don't produce the same checksum
+func writeChecksumEntry(w io.Writer, key string, value []byte) {
+	fmt.Fprintf(w, "%d:%s%d:", len(key), key, len(value))
+	_, _ = w.Write(value)
 }
 
 func UpdateKedaControllerStatus(ctx context.Context, cl client.Client, kedaController *kedav1alpha1.KedaController, status *kedav1alpha1.KedaControllerStatus) error {
@@ -119,6 +109,45 @@ func HasPodMonitorCRD(ctx context.Context, logger logr.Logger, cl client.Client)
 	return isGvkPresent(ctx, logger, cl, gvk)
 }
 
+// HasPrometheusRuleCRD returns true if the PrometheusRule CRD is present in the cluster, false otherwise
+func HasPrometheusRuleCRD(ctx context.Context, logger logr.Logger, cl client.Client) bool {
+	gvk := schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+	return isGvkPresent(ctx, logger, cl, gvk)
+}
+
+// HasOpenshiftConfigAPI returns whether the cluster serves the given kind of the OpenShift config.openshift.io/v1 API,
+// it is asked through discovery as the client cache isn't started yet when the watches are set up
+func HasOpenshiftConfigAPI(logger logr.Logger, discoveryClient *discovery.DiscoveryClient, kind string) bool {
+	return HasAPI(logger, discoveryClient, schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: kind})
+}
+
+// HasCertManagerAPI returns whether the cluster serves the given kind of the cert-manager.io/v1 API,
+// it is asked through discovery as the client cache isn't started yet when the watches are set up
+func HasCertManagerAPI(logger logr.Logger, discoveryClient *discovery.DiscoveryClient, kind string) bool {
+	return HasAPI(logger, discoveryClient, schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: kind})
+}
+
+// HasAPI returns whether the cluster serves the given gvk according to discovery
+func HasAPI(logger logr.Logger, discoveryClient *discovery.DiscoveryClient, gvk schema.GroupVersionKind) bool {
+	if discoveryClient == nil {
+		return false
+	}
+	groupVersion := gvk.GroupVersion().String()
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Unable to discover the API", "groupVersion", groupVersion)
+		}
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
 // isGvkPresent returns whether the given gvk is present or not
 func isGvkPresent(ctx context.Context, logger logr.Logger, cl client.Client, gvk schema.GroupVersionKind) bool {
 	list := &unstructured.UnstructuredList{}
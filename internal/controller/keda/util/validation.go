@@ -0,0 +1,115 @@
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+var (
+	LogLevels        = []string{"debug", "info", "error"}
+	LogEncoders      = []string{"json", "console"}
+	LogTimeEncodings = []string{"epoch", "millis", "nano", "iso8601", "rfc3339", "rfc3339nano"}
+	AuditLogFormats  = []string{"legacy", "json"}
+
+	// ReservedVolumeNames are the names of the volumes the KEDA components depend on
+	ReservedVolumeNames = []string{"certificates", "temp-vol", "audit-policy", "audit-log"}
+	// ReservedContainerNames are the names of the containers of the KEDA components
+	ReservedContainerNames = []string{"keda-operator", "keda-metrics-apiserver", "keda-admission-webhooks"}
+)
+
+// volumes with CA bundles mounted into KEDA Operator are named cabundle0, cabundle1, etc.
+const caBundleVolumePrefix = "cabundle"
+
+// IsReservedVolumeName checks whether name is used by a volume the KEDA components depend on
+func IsReservedVolumeName(name string) bool {
+	return slices.Contains(ReservedVolumeNames, name) || strings.HasPrefix(name, caBundleVolumePrefix)
+}
+
+// IsValidLogLevel checks the log level of KEDA Operator and KEDA Admission Webhooks,
+// it is either one of LogLevels or an integer value greater than 0
+func IsValidLogLevel(logLevel string) bool {
+	if slices.Contains(LogLevels, logLevel) {
+		return true
+	}
+	_, err := strconv.ParseUint(logLevel, 10, 64)
+	return err == nil
+}
+
+// IsValidMetricsServerLogLevel checks the klog verbosity of KEDA Metrics Server
+func IsValidMetricsServerLogLevel(logLevel string) bool {
+	_, err := strconv.ParseUint(logLevel, 10, 64)
+	return err == nil
+}
+
+func IsValidLogEncoder(logEncoder string) bool {
+	return slices.Contains(LogEncoders, logEncoder)
+}
+
+func IsValidLogTimeEncoding(logTimeEncoding string) bool {
+	return slices.Contains(LogTimeEncodings, logTimeEncoding)
+}
+
+// ValidateArg checks an user-defined argument is in one of the formats '--argument=value',
+// 'argument=value' or 'value' and that it doesn't hide several arguments in one string
+func ValidateArg(arg string) error {
+	if strings.TrimSpace(arg) == "" {
+		return fmt.Errorf("argument must not be empty")
+	}
+	if strings.ContainsAny(arg, " \t\n") {
+		return fmt.Errorf("argument must not contain whitespace, use a separate argument for each value")
+	}
+	if name, _, found := strings.Cut(arg, "="); found && strings.TrimLeft(name, "-") == "" {
+		return fmt.Errorf("argument name must not be empty")
+	}
+	return nil
+}
+
+// ArgName returns the name of an user-defined argument without the leading dashes,
+// arguments without a value are returned as they are
+func ArgName(arg string) string {
+	name, _, found := strings.Cut(arg, "=")
+	if !found {
+		return arg
+	}
+	return strings.TrimLeft(name, "-")
+}
+
+// ValidateAuditLogVolumeWithArgs validates whether Volume can exist with lifetime
+// arguments. If name is empty (no volume given) -> validate lifetime args are
+// not given otherwise lt args would be useless for stdout logging.
+func ValidateAuditLogVolumeWithArgs(name string, ltArgs kedav1alpha1.AuditLifetime) error {
+	if name == "" {
+		var maxage int
+		var maxbackup int
+		var maxsize int
+		var err error
+
+		// check if lifetime args are given -> this would be error
+		if ltArgs.MaxAge != "" {
+			maxage, err = strconv.Atoi(ltArgs.MaxAge)
+			if err != nil {
+				return fmt.Errorf("bad conversion of string in audit flag maxAge")
+			}
+		}
+		if ltArgs.MaxBackup != "" {
+			maxbackup, err = strconv.Atoi(ltArgs.MaxBackup)
+			if err != nil {
+				return fmt.Errorf("bad conversion of string in audit flag maxBackup")
+			}
+		}
+		if ltArgs.MaxSize != "" {
+			maxsize, err = strconv.Atoi(ltArgs.MaxSize)
+			if err != nil {
+				return fmt.Errorf("bad conversion of string in audit flag maxSize")
+			}
+		}
+		if maxage >= 1 || maxbackup >= 1 || maxsize >= 1 {
+			return fmt.Errorf("bad flag combination - don't use lifetime arguments when logging to stdout")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// log is for logging in this package.
+var kedacontrollerlog = logf.Log.WithName("kedacontroller-resource")
+
+const (
+	// only the KedaController with this name is reconciled
+	kedaControllerResourceName = "keda"
+
+	externalMetricsAPIServiceName = "v1beta1.external.metrics.k8s.io"
+
+	// the Secret KEDA Operator and the OpenShift service CA rotator write the generated certificates to
+	grpcClientCertsSecretName = "kedaorg-certs"
+
+	defaultLogLevel              = "info"
+	defaultLogEncoder            = "console"
+	defaultLogTimeEncoding       = "rfc3339"
+	defaultMetricsServerLogLevel = "0"
+
+	// scrape interval of the monitors in the manifests
+	defaultScrapeInterval = 60 * time.Second
+)
+
+// ports used by the containers of KEDA components
+const (
+	metricsPort        int32 = 8080
+	healthProbePort    int32 = 8081
+	metricsServicePort int32 = 9666
+	webhookServerPort  int32 = 9443
+	defaultSecurePort  int32 = 6443
+)
+
+var (
+	operatorPorts          = []int32{metricsPort, healthProbePort, metricsServicePort}
+	admissionWebhooksPorts = []int32{metricsPort, healthProbePort, webhookServerPort}
+)
+
+// SetupKedaControllerWebhookWithManager registers the webhooks for KedaController in the manager,
+// installNamespace is the only namespace the KedaController is reconciled in
+func SetupKedaControllerWebhookWithManager(mgr ctrl.Manager, installNamespace string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&kedav1alpha1.KedaController{}).
+		WithValidator(&KedaControllerCustomValidator{InstallNamespace: installNamespace}).
+		WithDefaulter(&KedaControllerCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-keda-sh-v1alpha1-kedacontroller,mutating=true,failurePolicy=fail,sideEffects=None,groups=keda.sh,resources=kedacontrollers,verbs=create;update,versions=v1alpha1,name=mkedacontroller-v1alpha1.keda.sh,admissionReviewVersions=v1
+
+// KedaControllerCustomDefaulter fills the documented defaults of a KedaController
+type KedaControllerCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KedaControllerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KedaController.
+func (d *KedaControllerCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	kedaController, ok := obj.(*kedav1alpha1.KedaController)
+	if !ok {
+		return fmt.Errorf("expected a KedaController object but got %T", obj)
+	}
+	kedacontrollerlog.Info("Defaulting for KedaController", "name", kedaController.GetName())
+
+	spec := &kedaController.Spec
+	setDefault(&spec.Operator.LogLevel, defaultLogLevel)
+	setDefault(&spec.Operator.LogEncoder, defaultLogEncoder)
+	setDefault(&spec.Operator.LogTimeEncoding, defaultLogTimeEncoding)
+	setDefault(&spec.MetricsServer.LogLevel, defaultMetricsServerLogLevel)
+	setDefault(&spec.AdmissionWebhooks.LogLevel, defaultLogLevel)
+	setDefault(&spec.AdmissionWebhooks.LogEncoder, defaultLogEncoder)
+	setDefault(&spec.AdmissionWebhooks.LogTimeEncoding, defaultLogTimeEncoding)
+
+	return nil
+}
+
+func setDefault(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-kedacontroller,mutating=false,failurePolicy=fail,sideEffects=None,groups=keda.sh,resources=kedacontrollers,verbs=create;update,versions=v1alpha1,name=vkedacontroller-v1alpha1.keda.sh,admissionReviewVersions=v1
+
+// KedaControllerCustomValidator rejects KedaController resources the operator can't install KEDA from,
+// it also warns about fields that are deprecated or have no effect
+type KedaControllerCustomValidator struct {
+	InstallNamespace string
+}
+
+var _ webhook.CustomValidator = &KedaControllerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KedaController.
+func (v *KedaControllerCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kedaController, ok := obj.(*kedav1alpha1.KedaController)
+	if !ok {
+		return nil, fmt.Errorf("expected a KedaController object but got %T", obj)
+	}
+	kedacontrollerlog.Info("Validation for KedaController upon creation", "name", kedaController.GetName())
+
+	return v.validateKedaController(kedaController)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KedaController.
+func (v *KedaControllerCustomValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	kedaController, ok := newObj.(*kedav1alpha1.KedaController)
+	if !ok {
+		return nil, fmt.Errorf("expected a KedaController object for the newObj but got %T", newObj)
+	}
+	kedacontrollerlog.Info("Validation for KedaController upon update", "name", kedaController.GetName())
+
+	// a KedaController being deleted has to be updated to remove its finalizer
+	if kedaController.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	return v.validateKedaController(kedaController)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KedaController.
+func (v *KedaControllerCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// specValidator validates a section of the spec of a KedaController, the sections are validated independently of
+// each other except for fields of other sections they depend on
+type specValidator func(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings)
+
+func (v *KedaControllerCustomValidator) validateKedaController(kedaController *kedav1alpha1.KedaController) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	if kedaController.Name != kedaControllerResourceName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), kedaController.Name,
+			fmt.Sprintf("the KedaController resource needs to be named %s, otherwise it will be ignored", kedaControllerResourceName)))
+	}
+	if v.InstallNamespace != "" && kedaController.Namespace != v.InstallNamespace {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "namespace"), kedaController.Namespace,
+			fmt.Sprintf("the KedaController resource needs to be created in namespace %s, otherwise it will be ignored", v.InstallNamespace)))
+	}
+
+	specPath := field.NewPath("spec")
+	for _, validate := range []specValidator{
+		validateGeneralSpec,
+		validateCertificatesSpec,
+		validateOperatorSpec,
+		validateMetricsServerSpec,
+		validateAdmissionWebhooksSpec,
+		validateMonitoringSpec,
+	} {
+		errs, sectionWarnings := validate(specPath, kedaController.Spec)
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, sectionWarnings...)
+	}
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(kedav1alpha1.GroupVersion.WithKind("KedaController").GroupKind(), kedaController.Name, allErrs)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+// validateCertificatesSpec validates the provider of the serving certificates and its settings
+func validateCertificatesSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	certificatesPath := specPath.Child("certificates")
+	allErrs = append(allErrs, validateCertificates(certificatesPath, spec.Certificates)...)
+	allErrs = append(allErrs, validateUserProvidedCertificates(certificatesPath.Child("userProvided"), spec)...)
+	if externalCertificatesProvider(spec.Certificates.Provider) && spec.Operator.CertRotation != nil && *spec.Operator.CertRotation {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("operator", "certRotation"), *spec.Operator.CertRotation,
+			fmt.Sprintf("KEDA Operator can't rotate the certificates of the %s provider", spec.Certificates.Provider)))
+	}
+	if spec.Certificates.Provider != kedav1alpha1.CertificatesProviderCertManager && spec.Certificates.CertManager != (kedav1alpha1.CertManagerSpec{}) {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored unless %s is %s", certificatesPath.Child("certManager"),
+			certificatesPath.Child("provider"), kedav1alpha1.CertificatesProviderCertManager))
+	}
+	if spec.Certificates.Provider != kedav1alpha1.CertificatesProviderUserProvided &&
+		spec.Certificates.UserProvided != (kedav1alpha1.UserProvidedCertificatesSpec{}) {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored unless %s is %s", certificatesPath.Child("userProvided"),
+			certificatesPath.Child("provider"), kedav1alpha1.CertificatesProviderUserProvided))
+	}
+	return allErrs, warnings
+}
+
+// validateCertificates validates the issuer and the lifetime of the cert-manager Certificate
+func validateCertificates(path *field.Path, spec kedav1alpha1.CertificatesSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	certManagerPath := path.Child("certManager")
+	certManager := spec.CertManager
+	if ref := certManager.IssuerRef; ref != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(certManagerPath.Child("issuerRef", "name"), ref.Name, msg))
+		}
+	}
+	if certManager.Duration != nil && certManager.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(certManagerPath.Child("duration"), certManager.Duration.Duration.String(), "needs to be positive"))
+	}
+	if certManager.RenewBefore != nil {
+		renewBefore := certManager.RenewBefore.Duration
+		if renewBefore <= 0 {
+			allErrs = append(allErrs, field.Invalid(certManagerPath.Child("renewBefore"), renewBefore.String(), "needs to be positive"))
+		} else if certManager.Duration != nil && renewBefore >= certManager.Duration.Duration {
+			allErrs = append(allErrs, field.Invalid(certManagerPath.Child("renewBefore"), renewBefore.String(),
+				fmt.Sprintf("needs to be shorter than %s", certManagerPath.Child("duration"))))
+		}
+	}
+	return allErrs
+}
+
+// validateUserProvidedCertificates checks that the Secrets of the enabled KEDA components and the CA bundle are set
+// with the 'user-provided' certificates provider and that all references are valid names
+func validateUserProvidedCertificates(path *field.Path, spec kedav1alpha1.KedaControllerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	userProvided := spec.Certificates.UserProvided
+	required := spec.Certificates.Provider == kedav1alpha1.CertificatesProviderUserProvided
+	for _, secret := range []struct {
+		field    string
+		name     string
+		required bool
+	}{
+		{"metricsServerSecret", userProvided.MetricsServerSecret, required && spec.MetricsServer.IsEnabled()},
+		{"admissionWebhooksSecret", userProvided.AdmissionWebhooksSecret, required && spec.AdmissionWebhooks.IsEnabled()},
+		{"grpcSecret", userProvided.GRPCSecret, required},
+	} {
+		if secret.name == "" {
+			if secret.required {
+				allErrs = append(allErrs, field.Required(path.Child(secret.field),
+					fmt.Sprintf("needs to be set with the %s certificates provider", kedav1alpha1.CertificatesProviderUserProvided)))
+			}
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(secret.name) {
+			allErrs = append(allErrs, field.Invalid(path.Child(secret.field), secret.name, msg))
+		}
+		if secret.name == grpcClientCertsSecretName {
+			allErrs = append(allErrs, field.Invalid(path.Child(secret.field), secret.name, generatedSecretMessage))
+		}
+	}
+	if required || userProvided.CABundle != (kedav1alpha1.CASource{}) {
+		allErrs = append(allErrs, validateCASource(path.Child("caBundle"), userProvided.CABundle)...)
+	}
+	if userProvided.CABundle.Secret == grpcClientCertsSecretName {
+		allErrs = append(allErrs, field.Invalid(path.Child("caBundle", "secret"), userProvided.CABundle.Secret, generatedSecretMessage))
+	}
+	return allErrs
+}
+
+// generatedSecretMessage rejects a user-provided Secret which would be overwritten with generated certificates
+var generatedSecretMessage = fmt.Sprintf("the Secret %s holds the certificates generated by KEDA Operator, "+
+	"which may overwrite it, the certificates need to be provided in another Secret", grpcClientCertsSecretName)
+
+// externalCertificatesProvider returns whether the certificates are issued outside of KEDA Operator and the OpenShift
+// service CA operator, so KEDA Operator neither rotates them nor injects its CA
+func externalCertificatesProvider(provider kedav1alpha1.CertificatesProvider) bool {
+	return provider == kedav1alpha1.CertificatesProviderCertManager || provider == kedav1alpha1.CertificatesProviderUserProvided
+}
+
+// validateTrustedCA validates the CA sources of a KEDA component, each of them references either a ConfigMap or a Secret
+func validateTrustedCA(path *field.Path, spec kedav1alpha1.TrustedCASpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, source := range spec.CASources {
+		allErrs = append(allErrs, validateCASource(path.Child("caSources").Index(i), source)...)
+	}
+	return allErrs
+}
+
+// validateCASource checks that exactly one of the ConfigMap or the Secret of a CA source is set to a valid name
+func validateCASource(path *field.Path, source kedav1alpha1.CASource) field.ErrorList {
+	var allErrs field.ErrorList
+	switch {
+	case source.ConfigMap == "" && source.Secret == "":
+		allErrs = append(allErrs, field.Required(path, "either configMap or secret needs to be set"))
+	case source.ConfigMap != "" && source.Secret != "":
+		allErrs = append(allErrs, field.Forbidden(path, "only one of configMap or secret may be set"))
+	}
+	if source.ConfigMap != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(source.ConfigMap) {
+			allErrs = append(allErrs, field.Invalid(path.Child("configMap"), source.ConfigMap, msg))
+		}
+	}
+	if source.Secret != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(source.Secret) {
+			allErrs = append(allErrs, field.Invalid(path.Child("secret"), source.Secret, msg))
+		}
+	}
+	return allErrs
+}
+
+// trustedCADirString describes the SSL_CERT_DIR rendered from the trusted CAs of KEDA Metrics Server and Admission Webhooks,
+// empty without any
+func trustedCADirString(spec kedav1alpha1.TrustedCASpec) string {
+	configMaps, secrets := util.CAReferences(spec.CASources)
+	count := len(configMaps) + len(secrets)
+	if spec.ClusterTrustedCABundle {
+		count++
+	}
+	if count == 0 {
+		return ""
+	}
+	dirs := []string{"/etc/ssl/certs"}
+	for i := 0; i < count; i++ {
+		dirs = append(dirs, "/custom/ca"+strconv.Itoa(i))
+	}
+	return strings.Join(dirs, ":")
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+// validateOperatorSpec validates the settings of KEDA Operator
+func validateOperatorSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	operatorPath := specPath.Child("operator")
+	allErrs = append(allErrs, validateLogging(operatorPath, spec.Operator.LogLevel, spec.Operator.LogEncoder, spec.Operator.LogTimeEncoding)...)
+	allErrs = append(allErrs, validateDeployment(operatorPath, spec.Operator.GenericDeploymentSpec)...)
+	allErrs = append(allErrs, validateArgs(operatorPath.Child("args"), spec.Operator.Args)...)
+	warnings = append(warnings, deploymentWarnings(operatorPath, spec.Operator.GenericDeploymentSpec)...)
+	allErrs = append(allErrs, validateRuntime(operatorPath, spec.Operator.GenericRuntimeSpec, operatorPorts)...)
+	allErrs = append(allErrs, validateTrustedCA(operatorPath, spec.Operator.TrustedCASpec)...)
+	for i, dir := range spec.Operator.CADirs {
+		if !path.IsAbs(dir) {
+			allErrs = append(allErrs, field.Invalid(operatorPath.Child("caDirs").Index(i), dir, "needs to be an absolute path"))
+		}
+	}
+	warnings = append(warnings, runtimeWarnings(operatorPath, spec.Operator.GenericRuntimeSpec)...)
+	_, kedaTLSMinVersion, _ := tlsProfileStrings(spec.TLSProfile)
+	operatorEnvFields := map[string]string{
+		"WATCH_NAMESPACE":           watchNamespaceString(spec),
+		"KEDA_HTTP_DEFAULT_TIMEOUT": durationString(spec.Operator.HTTPTimeout),
+		"HTTP_PROXY":                spec.Proxy.HTTPProxy,
+		"HTTPS_PROXY":               spec.Proxy.HTTPSProxy,
+		"NO_PROXY":                  spec.Proxy.NoProxy,
+		"KEDA_HTTP_MIN_TLS_VERSION": kedaTLSMinVersion,
+	}
+	podIdentityEnv, _ := podIdentityStrings(spec.PodIdentity)
+	for name, value := range podIdentityEnv {
+		operatorEnvFields[name] = value
+	}
+	warnings = append(warnings, overriddenEnvWarnings(operatorPath, spec.Operator.Env, operatorEnvFields)...)
+	warnings = append(warnings, overriddenFieldWarnings(operatorPath, spec.Operator.Args, runtimeArgs(spec.Operator.GenericRuntimeSpec, map[string]string{
+		"zap-log-level":        spec.Operator.LogLevel,
+		"zap-encoder":          spec.Operator.LogEncoder,
+		"zap-time-encoding":    spec.Operator.LogTimeEncoding,
+		"enable-cert-rotation": boolString(spec.Operator.CertRotation),
+	}))...)
+	return allErrs, warnings
+}
+
+// validateMetricsServerSpec validates the settings of KEDA Metrics Server
+func validateMetricsServerSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	metricsServerPath := specPath.Child("metricsServer")
+	if spec.MetricsServer.LogLevel != "" && !util.IsValidMetricsServerLogLevel(spec.MetricsServer.LogLevel) {
+		allErrs = append(allErrs, field.Invalid(metricsServerPath.Child("logLevel"), spec.MetricsServer.LogLevel,
+			"needs to be set to an integer value greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, validateDeployment(metricsServerPath, spec.MetricsServer.GenericDeploymentSpec)...)
+	allErrs = append(allErrs, validateArgs(metricsServerPath.Child("args"), spec.MetricsServer.Args)...)
+	allErrs = append(allErrs, validateAuditConfig(metricsServerPath.Child("auditConfig"), spec.MetricsServer.AuditConfig)...)
+	warnings = append(warnings, deploymentWarnings(metricsServerPath, spec.MetricsServer.GenericDeploymentSpec)...)
+	warnings = append(warnings, auditConfigWarnings(metricsServerPath.Child("auditConfig"), spec.MetricsServer.AuditConfig)...)
+	metricsServerPorts := []int32{metricsPort, defaultSecurePort}
+	if spec.MetricsServer.SecurePort != nil {
+		metricsServerPorts = []int32{metricsPort, *spec.MetricsServer.SecurePort}
+		if *spec.MetricsServer.SecurePort == metricsPort {
+			allErrs = append(allErrs, field.Invalid(metricsServerPath.Child("securePort"), *spec.MetricsServer.SecurePort,
+				fmt.Sprintf("port %d is used by the metrics endpoint", metricsPort)))
+		}
+	}
+	allErrs = append(allErrs, validateRuntime(metricsServerPath, spec.MetricsServer.GenericRuntimeSpec, metricsServerPorts)...)
+	allErrs = append(allErrs, validateTrustedCA(metricsServerPath, spec.MetricsServer.TrustedCASpec)...)
+	if address := spec.MetricsServer.GRPC.MetricsServiceAddress; address != "" {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			allErrs = append(allErrs, field.Invalid(metricsServerPath.Child("grpc", "metricsServiceAddress"), address, "needs to be in the format 'host:port'"))
+		}
+	}
+	warnings = append(warnings, runtimeWarnings(metricsServerPath, spec.MetricsServer.GenericRuntimeSpec)...)
+	warnings = append(warnings, overriddenEnvWarnings(metricsServerPath, spec.MetricsServer.Env, map[string]string{
+		"KEDA_HTTP_DEFAULT_TIMEOUT": durationString(spec.MetricsServer.HTTPTimeout),
+		"SSL_CERT_DIR":              trustedCADirString(spec.MetricsServer.TrustedCASpec),
+	})...)
+	tlsMinVersion, _, tlsCipherSuites := tlsProfileStrings(spec.TLSProfile)
+	warnings = append(warnings, overriddenFieldWarnings(metricsServerPath, spec.MetricsServer.Args, runtimeArgs(spec.MetricsServer.GenericRuntimeSpec, map[string]string{
+		"v":                              spec.MetricsServer.LogLevel,
+		"secure-port":                    int32String(spec.MetricsServer.SecurePort),
+		"metrics-service-address":        spec.MetricsServer.GRPC.MetricsServiceAddress,
+		"metrics-service-grpc-authority": spec.MetricsServer.GRPC.MetricsServiceAuthority,
+		"tls-min-version":                tlsMinVersion,
+		"tls-cipher-suites":              tlsCipherSuites,
+	}))...)
+	if !spec.MetricsServer.IsEnabled() && !externalCertificatesProvider(spec.Certificates.Provider) &&
+		(spec.Operator.CertRotation == nil || *spec.Operator.CertRotation) {
+		warnings = append(warnings, fmt.Sprintf("%s is false, but KEDA Operator rotating the certificates still injects its CA into the APIService %s, "+
+			"set %s to false when another metrics adapter serves it", metricsServerPath.Child("enabled"), externalMetricsAPIServiceName,
+			specPath.Child("operator", "certRotation")))
+	}
+	return allErrs, warnings
+}
+
+// validateAdmissionWebhooksSpec validates the settings of KEDA Admission Webhooks
+func validateAdmissionWebhooksSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	admissionWebhooksPath := specPath.Child("admissionWebhooks")
+	allErrs = append(allErrs, validateLogging(admissionWebhooksPath, spec.AdmissionWebhooks.LogLevel, spec.AdmissionWebhooks.LogEncoder, spec.AdmissionWebhooks.LogTimeEncoding)...)
+	allErrs = append(allErrs, validateDeployment(admissionWebhooksPath, spec.AdmissionWebhooks.GenericDeploymentSpec)...)
+	allErrs = append(allErrs, validateArgs(admissionWebhooksPath.Child("args"), spec.AdmissionWebhooks.Args)...)
+	warnings = append(warnings, deploymentWarnings(admissionWebhooksPath, spec.AdmissionWebhooks.GenericDeploymentSpec)...)
+	allErrs = append(allErrs, validateRuntime(admissionWebhooksPath, spec.AdmissionWebhooks.GenericRuntimeSpec, admissionWebhooksPorts)...)
+	allErrs = append(allErrs, validateTrustedCA(admissionWebhooksPath, spec.AdmissionWebhooks.TrustedCASpec)...)
+	warnings = append(warnings, runtimeWarnings(admissionWebhooksPath, spec.AdmissionWebhooks.GenericRuntimeSpec)...)
+	_, kedaTLSMinVersion, _ := tlsProfileStrings(spec.TLSProfile)
+	warnings = append(warnings, overriddenEnvWarnings(admissionWebhooksPath, spec.AdmissionWebhooks.Env, map[string]string{
+		"KEDA_HTTP_DEFAULT_TIMEOUT": durationString(spec.AdmissionWebhooks.HTTPTimeout),
+		"KEDA_HTTP_MIN_TLS_VERSION": kedaTLSMinVersion,
+		"SSL_CERT_DIR":              trustedCADirString(spec.AdmissionWebhooks.TrustedCASpec),
+	})...)
+	warnings = append(warnings, overriddenFieldWarnings(admissionWebhooksPath, spec.AdmissionWebhooks.Args, runtimeArgs(spec.AdmissionWebhooks.GenericRuntimeSpec, map[string]string{
+		"zap-log-level":     spec.AdmissionWebhooks.LogLevel,
+		"zap-encoder":       spec.AdmissionWebhooks.LogEncoder,
+		"zap-time-encoding": spec.AdmissionWebhooks.LogTimeEncoding,
+	}))...)
+	return allErrs, warnings
+}
+
+func validateLogging(path *field.Path, logLevel, logEncoder, logTimeEncoding string) field.ErrorList {
+	var allErrs field.ErrorList
+	if logLevel != "" && !util.IsValidLogLevel(logLevel) {
+		allErrs = append(allErrs, field.Invalid(path.Child("logLevel"), logLevel,
+			fmt.Sprintf("needs to be set to one of %s or an integer value greater than 0", strings.Join(util.LogLevels, ", "))))
+	}
+	if logEncoder != "" && !util.IsValidLogEncoder(logEncoder) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("logEncoder"), logEncoder, util.LogEncoders))
+	}
+	if logTimeEncoding != "" && !util.IsValidLogTimeEncoding(logTimeEncoding) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("logTimeEncoding"), logTimeEncoding, util.LogTimeEncodings))
+	}
+	return allErrs
+}
+
+// validateRuntime checks the runtime settings of a KEDA component, ports are the ports already used by the component
+func validateRuntime(path *field.Path, spec kedav1alpha1.GenericRuntimeSpec, ports []int32) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.HTTPTimeout != nil && spec.HTTPTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("httpTimeout"), spec.HTTPTimeout.Duration.String(), "needs to be a positive duration"))
+	}
+	if address := spec.ProfilingBindAddress; address != "" {
+		_, portStr, err := net.SplitHostPort(address)
+		port, portErr := strconv.ParseUint(portStr, 10, 16)
+		switch {
+		case err != nil || portErr != nil || port == 0:
+			allErrs = append(allErrs, field.Invalid(path.Child("profilingBindAddress"), address, "needs to be in the format '[host]:port'"))
+		case slices.Contains(ports, int32(port)):
+			allErrs = append(allErrs, field.Invalid(path.Child("profilingBindAddress"), address, fmt.Sprintf("port %d is already used by the component", port)))
+		}
+	}
+	return allErrs
+}
+
+func runtimeWarnings(path *field.Path, spec kedav1alpha1.GenericRuntimeSpec) admission.Warnings {
+	var warnings admission.Warnings
+	if spec.KubeAPIQPS != nil && spec.KubeAPIBurst != nil && *spec.KubeAPIBurst < *spec.KubeAPIQPS {
+		warnings = append(warnings, fmt.Sprintf("%s is lower than %s, which limits the queries per second to the burst", path.Child("kubeAPIBurst"), path.Child("kubeAPIQPS")))
+	}
+	return warnings
+}
+
+// runtimeArgs adds the arguments rendered from the runtime settings to args
+func runtimeArgs(spec kedav1alpha1.GenericRuntimeSpec, args map[string]string) map[string]string {
+	args["kube-api-qps"] = int32String(spec.KubeAPIQPS)
+	args["kube-api-burst"] = int32String(spec.KubeAPIBurst)
+	args["profiling-bind-address"] = spec.ProfilingBindAddress
+	return args
+}
+
+func validateArgs(path *field.Path, args []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, arg := range args {
+		if err := util.ValidateArg(arg); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), arg, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateAuditConfig(path *field.Path, auditConfig kedav1alpha1.AuditConfig) field.ErrorList {
+	var allErrs field.ErrorList
+	if auditConfig.LogFormat != "" && !slices.Contains(util.AuditLogFormats, auditConfig.LogFormat) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("logFormat"), auditConfig.LogFormat, util.AuditLogFormats))
+	}
+
+	lifetimePath := path.Child("lifetime")
+	for _, lifetime := range []struct {
+		name  string
+		value string
+	}{
+		{"maxAge", auditConfig.AuditLifetime.MaxAge},
+		{"maxBackup", auditConfig.AuditLifetime.MaxBackup},
+		{"maxSize", auditConfig.AuditLifetime.MaxSize},
+	} {
+		if lifetime.value == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(lifetime.value, 10, 32); err != nil {
+			allErrs = append(allErrs, field.Invalid(lifetimePath.Child(lifetime.name), lifetime.value, "needs to be a non-negative integer"))
+		}
+	}
+
+	if !reflect.DeepEqual(auditConfig.Policy, kedav1alpha1.AuditPolicy{}) {
+		if err := util.ValidateAuditLogVolumeWithArgs(auditConfig.LogOutputVolumeClaim, auditConfig.AuditLifetime); err != nil {
+			allErrs = append(allErrs, field.Invalid(lifetimePath, auditConfig.AuditLifetime, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func auditConfigWarnings(path *field.Path, auditConfig kedav1alpha1.AuditConfig) admission.Warnings {
+	var warnings admission.Warnings
+	if reflect.DeepEqual(auditConfig.Policy, kedav1alpha1.AuditPolicy{}) && !reflect.DeepEqual(auditConfig, kedav1alpha1.AuditConfig{}) {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored, audit logging is enabled only when %s is set", path, path.Child("policy")))
+	}
+	return warnings
+}
+
+// overriddenFieldWarnings warns when an user-defined argument overrides an argument rendered from a dedicated field,
+// fields maps the argument names to the values of the dedicated fields
+func overriddenFieldWarnings(path *field.Path, args []string, fields map[string]string) admission.Warnings {
+	var warnings admission.Warnings
+	for i, arg := range args {
+		if value, found := fields[util.ArgName(arg)]; found && value != "" {
+			warnings = append(warnings, fmt.Sprintf("%s overrides the value '%s' set by a dedicated field", path.Child("args").Index(i), value))
+		}
+	}
+	return warnings
+}
+
+// overriddenAnnotationWarnings warns when an user-defined annotation overrides an annotation rendered from a dedicated field,
+// fields maps the annotation keys to the values of the dedicated fields
+func overriddenAnnotationWarnings(path *field.Path, annotations map[string]string, fields map[string]string) admission.Warnings {
+	var warnings admission.Warnings
+	for key := range annotations {
+		if value, found := fields[key]; found && value != "" {
+			warnings = append(warnings, fmt.Sprintf("%s overrides the value '%s' set by a dedicated field", path.Child("annotations").Key(key), value))
+		}
+	}
+	slices.Sort(warnings)
+	return warnings
+}
+
+// overriddenEnvWarnings warns when an user-defined environment variable overrides a variable rendered from a dedicated field,
+// fields maps the variable names to the values of the dedicated fields
+func overriddenEnvWarnings(path *field.Path, env []corev1.EnvVar, fields map[string]string) admission.Warnings {
+	var warnings admission.Warnings
+	for i, envVar := range env {
+		if value, found := fields[envVar.Name]; found && value != "" {
+			warnings = append(warnings, fmt.Sprintf("%s overrides the value '%s' set by a dedicated field", path.Child("env").Index(i), value))
+		}
+	}
+	return warnings
+}
+
+func int32String(i *int32) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*i))
+}
+
+func boolString(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func durationString(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.Duration.String()
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+func validateDeployment(path *field.Path, spec kedav1alpha1.GenericDeploymentSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if pdb := spec.PodDisruptionBudget; pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("podDisruptionBudget"), "only one of minAvailable and maxUnavailable can be set"))
+	}
+	if spec.Image != "" && strings.ContainsAny(spec.Image, " \t\n") {
+		allErrs = append(allErrs, field.Invalid(path.Child("image"), spec.Image, "must not contain whitespace"))
+	}
+	if sc := spec.PodSecurityContext; sc != nil {
+		allErrs = append(allErrs, validateSeccompProfile(path.Child("podSecurityContext", "seccompProfile"), sc.SeccompProfile)...)
+	}
+	if sc := spec.ContainerSecurityContext; sc != nil {
+		allErrs = append(allErrs, validateSeccompProfile(path.Child("containerSecurityContext", "seccompProfile"), sc.SeccompProfile)...)
+	}
+	allErrs = append(allErrs, validateProbe(path.Child("livenessProbe"), spec.LivenessProbe, true)...)
+	allErrs = append(allErrs, validateProbe(path.Child("readinessProbe"), spec.ReadinessProbe, false)...)
+	allErrs = append(allErrs, validateVolumes(path.Child("volumes"), spec.Volumes)...)
+	allErrs = append(allErrs, validateContainers(path.Child("initContainers"), spec.InitContainers)...)
+	allErrs = append(allErrs, validateContainers(path.Child("sidecars"), spec.Sidecars)...)
+	for i, env := range spec.Env {
+		if env.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("env").Index(i).Child("name"), ""))
+		}
+	}
+	for i, source := range spec.EnvFrom {
+		if (source.ConfigMapRef == nil) == (source.SecretRef == nil) {
+			allErrs = append(allErrs, field.Invalid(path.Child("envFrom").Index(i), source, "exactly one of configMapRef and secretRef must be set"))
+		}
+	}
+	return allErrs
+}
+
+// validateSeccompProfile checks that a localhost profile is set if and only if the type is Localhost
+func validateSeccompProfile(path *field.Path, profile *corev1.SeccompProfile) field.ErrorList {
+	var allErrs field.ErrorList
+	if profile == nil {
+		return allErrs
+	}
+	localhostProfile := profile.LocalhostProfile != nil && *profile.LocalhostProfile != ""
+	if profile.Type == corev1.SeccompProfileTypeLocalhost && !localhostProfile {
+		allErrs = append(allErrs, field.Required(path.Child("localhostProfile"), "must be set when type is Localhost"))
+	}
+	if profile.Type != corev1.SeccompProfileTypeLocalhost && profile.LocalhostProfile != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("localhostProfile"), "can only be set when type is Localhost"))
+	}
+	return allErrs
+}
+
+// validateProbe checks the fields of a probe override, the fields left at 0 keep their default value
+func validateProbe(path *field.Path, probe *corev1.Probe, liveness bool) field.ErrorList {
+	var allErrs field.ErrorList
+	if probe == nil {
+		return allErrs
+	}
+	for _, f := range []struct {
+		name  string
+		value int32
+	}{
+		{"initialDelaySeconds", probe.InitialDelaySeconds},
+		{"timeoutSeconds", probe.TimeoutSeconds},
+		{"periodSeconds", probe.PeriodSeconds},
+		{"successThreshold", probe.SuccessThreshold},
+		{"failureThreshold", probe.FailureThreshold},
+	} {
+		if f.value < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(f.name), f.value, "must not be negative"))
+		}
+	}
+	if liveness && probe.SuccessThreshold > 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("successThreshold"), probe.SuccessThreshold, "must be 1 for liveness probes"))
+	}
+	return allErrs
+}
+
+func validateVolumes(path *field.Path, volumes []corev1.Volume) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(volumes))
+	for i, volume := range volumes {
+		switch {
+		case volume.Name == "":
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("name"), ""))
+		case util.IsReservedVolumeName(volume.Name):
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("name"), volume.Name, "the name is reserved for a volume of the component"))
+		case names[volume.Name]:
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), volume.Name))
+		}
+		names[volume.Name] = true
+	}
+	return allErrs
+}
+
+func validateContainers(path *field.Path, containers []corev1.Container) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(containers))
+	for i, container := range containers {
+		switch {
+		case container.Name == "":
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("name"), ""))
+		case slices.Contains(util.ReservedContainerNames, container.Name):
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("name"), container.Name, "the name is reserved for the container of a component"))
+		case names[container.Name]:
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), container.Name))
+		}
+		names[container.Name] = true
+		if container.Image == "" {
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("image"), ""))
+		}
+	}
+	return allErrs
+}
+
+func deploymentWarnings(path *field.Path, spec kedav1alpha1.GenericDeploymentSpec) admission.Warnings {
+	var warnings admission.Warnings
+	if spec.PodDisruptionBudget != nil && (spec.Replicas == nil || *spec.Replicas <= 1) {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored, a PodDisruptionBudget is only created with more than 1 replica", path.Child("podDisruptionBudget")))
+	}
+	if sc := spec.ContainerSecurityContext; sc != nil {
+		if sc.Privileged != nil && *sc.Privileged {
+			warnings = append(warnings, fmt.Sprintf("%s runs the container privileged, the KEDA components don't need it", path.Child("containerSecurityContext", "privileged")))
+		}
+		if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+			warnings = append(warnings, fmt.Sprintf("%s allows privilege escalation, the KEDA components don't need it", path.Child("containerSecurityContext", "allowPrivilegeEscalation")))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	"github.com/kedacore/keda-olm-operator/internal/controller/keda/util"
+)
+
+// validateGeneralSpec validates the settings shared by all KEDA components
+func validateGeneralSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	if mirror := spec.ImageRegistryMirror; mirror != "" {
+		if strings.Contains(mirror, "://") || strings.ContainsAny(mirror, " \t\n@") {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("imageRegistryMirror"), mirror,
+				"needs to be a registry host optionally followed by a path, e.g. 'mirror.example.com/ghcr'"))
+		}
+	}
+
+	allErrs = append(allErrs, validateWatchNamespaces(specPath, spec)...)
+	allErrs = append(allErrs, validateProxy(specPath.Child("proxy"), spec.Proxy)...)
+	allErrs = append(allErrs, validateTLSProfile(specPath.Child("tlsProfile"), spec.TLSProfile)...)
+	allErrs = append(allErrs, validatePodIdentity(specPath.Child("podIdentity"), spec.PodIdentity)...)
+	allErrs = append(allErrs, validateOpenShiftMonitoring(specPath.Child("openshiftMonitoring"), spec.OpenShiftMonitoring, spec.PodIdentity)...)
+	if !spec.OpenShiftMonitoring.Enabled && spec.OpenShiftMonitoring.ClusterTriggerAuthentication != "" {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored while %s is false",
+			specPath.Child("openshiftMonitoring", "clusterTriggerAuthentication"), specPath.Child("openshiftMonitoring", "enabled")))
+	}
+	_, podIdentityAnnotations := podIdentityStrings(spec.PodIdentity)
+	warnings = append(warnings, overriddenAnnotationWarnings(specPath.Child("serviceAccount"), spec.ServiceAccount.Annotations, podIdentityAnnotations)...)
+	if spec.WatchNamespace != "" {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated, use %s instead", specPath.Child("watchNamespace"), specPath.Child("watchNamespaces")))
+	}
+	return allErrs, warnings
+}
+
+func validateWatchNamespaces(path *field.Path, spec kedav1alpha1.KedaControllerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.WatchNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.WatchNamespace) {
+			allErrs = append(allErrs, field.Invalid(path.Child("watchNamespace"), spec.WatchNamespace, msg))
+		}
+	}
+	for i, namespace := range spec.WatchNamespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(path.Child("watchNamespaces").Index(i), namespace, msg))
+		}
+	}
+	if spec.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	}
+	return allErrs
+}
+
+func validateProxy(path *field.Path, spec kedav1alpha1.ProxySpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, proxy := range []struct {
+		name  string
+		value string
+	}{
+		{"httpProxy", spec.HTTPProxy},
+		{"httpsProxy", spec.HTTPSProxy},
+	} {
+		if proxy.value == "" {
+			continue
+		}
+		if u, err := url.Parse(proxy.value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child(proxy.name), proxy.value, "needs to be an http or https URL, e.g. 'http://proxy.example.com:3128'"))
+		}
+	}
+	if spec.TrustedCAConfigMap != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.TrustedCAConfigMap) {
+			allErrs = append(allErrs, field.Invalid(path.Child("trustedCAConfigMap"), spec.TrustedCAConfigMap, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateTLSProfile checks that a custom profile is complete and that KEDA Metrics Server supports some of its ciphers
+func validateTLSProfile(path *field.Path, profile *configv1.TLSSecurityProfile) field.ErrorList {
+	var allErrs field.ErrorList
+	if profile == nil || profile.Type != configv1.TLSProfileCustomType {
+		return allErrs
+	}
+	customPath := path.Child("custom")
+	if profile.Custom == nil {
+		return append(allErrs, field.Required(customPath, "needs to be set for the Custom profile"))
+	}
+	if profile.Custom.MinTLSVersion == "" {
+		allErrs = append(allErrs, field.Required(customPath.Child("minTLSVersion"), "needs to be set for the Custom profile"))
+	}
+	// TLS 1.3 cipher suites are not configurable, so ciphers only matter below TLS 1.3
+	if profile.Custom.MinTLSVersion != configv1.VersionTLS13 && len(util.IANACipherSuites(profile.Custom.Ciphers)) == 0 {
+		allErrs = append(allErrs, field.Invalid(customPath.Child("ciphers"), strings.Join(profile.Custom.Ciphers, ","),
+			"needs to contain at least one TLS 1.2 cipher supported by KEDA Metrics Server"))
+	}
+	return allErrs
+}
+
+// validatePodIdentity checks the identifiers of the cloud workload identities and the names of their ClusterTriggerAuthentications
+func validatePodIdentity(path *field.Path, spec kedav1alpha1.PodIdentitySpec) field.ErrorList {
+	var allErrs field.ErrorList
+	triggerAuthentications := map[string]*field.Path{}
+	validateTriggerAuthentication := func(providerPath *field.Path, name string) {
+		if name == "" {
+			return
+		}
+		namePath := providerPath.Child("clusterTriggerAuthentication")
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(namePath, name, msg))
+		}
+		if other, found := triggerAuthentications[name]; found {
+			allErrs = append(allErrs, field.Duplicate(namePath, fmt.Sprintf("%s, it is already used by %s", name, other)))
+		}
+		triggerAuthentications[name] = namePath
+	}
+
+	if aws := spec.AWS; aws != nil {
+		awsPath := path.Child("aws")
+		if !strings.HasPrefix(aws.RoleARN, "arn:") || !strings.Contains(aws.RoleARN, ":role/") {
+			allErrs = append(allErrs, field.Invalid(awsPath.Child("roleArn"), aws.RoleARN,
+				"needs to be the ARN of an IAM role, e.g. 'arn:aws:iam::123456789012:role/keda-operator'"))
+		}
+		validateTriggerAuthentication(awsPath, aws.ClusterTriggerAuthentication)
+	}
+	if azure := spec.AzureWorkload; azure != nil {
+		azurePath := path.Child("azureWorkload")
+		if azure.ClientID == "" {
+			allErrs = append(allErrs, field.Required(azurePath.Child("clientId"), ""))
+		}
+		if azure.TenantID == "" {
+			allErrs = append(allErrs, field.Required(azurePath.Child("tenantId"), ""))
+		}
+		if host := azure.AuthorityHost; host != "" {
+			if u, err := url.Parse(host); err != nil || u.Scheme != "https" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(azurePath.Child("authorityHost"), host, "needs to be an https URL"))
+			}
+		}
+		validateTriggerAuthentication(azurePath, azure.ClusterTriggerAuthentication)
+	}
+	if gcp := spec.GCP; gcp != nil {
+		gcpPath := path.Child("gcp")
+		if !strings.Contains(gcp.ServiceAccountEmail, "@") {
+			allErrs = append(allErrs, field.Invalid(gcpPath.Child("serviceAccountEmail"), gcp.ServiceAccountEmail,
+				"needs to be the email of a GCP service account, e.g. 'keda-operator@project.iam.gserviceaccount.com'"))
+		}
+		if !strings.HasPrefix(gcp.Audience, "//iam.googleapis.com/") {
+			allErrs = append(allErrs, field.Invalid(gcpPath.Child("audience"), gcp.Audience,
+				"needs to be the audience of a workload identity pool provider, starting with '//iam.googleapis.com/'"))
+		}
+		validateTriggerAuthentication(gcpPath, gcp.ClusterTriggerAuthentication)
+	}
+	return allErrs
+}
+
+// validateOpenShiftMonitoring checks that the ClusterTriggerAuthentication for Thanos Querier has a valid name
+// which is not used by a pod identity
+func validateOpenShiftMonitoring(path *field.Path, spec kedav1alpha1.OpenShiftMonitoringSpec, podIdentity kedav1alpha1.PodIdentitySpec) field.ErrorList {
+	var allErrs field.ErrorList
+	name := spec.ClusterTriggerAuthentication
+	if !spec.Enabled || name == "" {
+		return allErrs
+	}
+	namePath := path.Child("clusterTriggerAuthentication")
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		allErrs = append(allErrs, field.Invalid(namePath, name, msg))
+	}
+	if provider, found := util.PodIdentityProviders(podIdentity)[name]; found {
+		allErrs = append(allErrs, field.Duplicate(namePath, fmt.Sprintf("%s, it is already used by the %s pod identity", name, provider)))
+	}
+	return allErrs
+}
+
+// podIdentityStrings describes the environment variables of KEDA Operator and the ServiceAccount annotations
+// rendered from the cloud workload identities, both keyed by name
+func podIdentityStrings(spec kedav1alpha1.PodIdentitySpec) (env map[string]string, annotations map[string]string) {
+	identity, err := util.PodIdentityFor(spec)
+	if err != nil {
+		return nil, nil
+	}
+	env = map[string]string{}
+	for _, envVar := range identity.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	return env, identity.ServiceAccountAnnotations
+}
+
+// tlsProfileStrings describes the minimum TLS version and cipher suites rendered from the TLS security profile of
+// the KedaController, empty when it is not set as the profile of the OpenShift APIServer is only known to the operator
+func tlsProfileStrings(profile *configv1.TLSSecurityProfile) (minVersion, kedaMinVersion, cipherSuites string) {
+	if profile == nil {
+		return "", "", ""
+	}
+	spec := util.ResolveTLSProfile(profile)
+	return string(spec.MinTLSVersion), util.KedaMinTLSVersion(spec.MinTLSVersion), strings.Join(util.IANACipherSuites(spec.Ciphers), ",")
+}
+
+// watchNamespaceString describes the namespaces rendered into WATCH_NAMESPACE, empty when KEDA watches all of them
+func watchNamespaceString(spec kedav1alpha1.KedaControllerSpec) string {
+	namespaces := slices.Clone(spec.WatchNamespaces)
+	if spec.WatchNamespace != "" {
+		namespaces = append(namespaces, spec.WatchNamespace)
+	}
+	if spec.NamespaceSelector != nil {
+		namespaces = append(namespaces, "namespaces matching namespaceSelector")
+	}
+	return strings.Join(namespaces, ",")
+}
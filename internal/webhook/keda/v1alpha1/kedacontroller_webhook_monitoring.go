@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+)
+
+// validateMonitoringSpec validates the monitoring resources of the KEDA components
+func validateMonitoringSpec(specPath *field.Path, spec kedav1alpha1.KedaControllerSpec) (field.ErrorList, admission.Warnings) {
+	monitoringPath := specPath.Child("monitoring")
+	return validateMonitoring(monitoringPath, spec.Monitoring), monitoringWarnings(monitoringPath, spec.Monitoring)
+}
+
+// validateMonitoring checks that the scrape timeout is not greater than the interval and that
+// the relabelings set the fields their action requires
+func validateMonitoring(path *field.Path, spec kedav1alpha1.MonitoringSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	interval := defaultScrapeInterval
+	if spec.Interval != "" {
+		d, err := time.ParseDuration(spec.Interval)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("interval"), spec.Interval, "needs to be a duration, e.g. '30s'"))
+		}
+		interval = d
+	}
+	if spec.ScrapeTimeout != "" {
+		scrapeTimeout, err := time.ParseDuration(spec.ScrapeTimeout)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("scrapeTimeout"), spec.ScrapeTimeout, "needs to be a duration, e.g. '10s'"))
+		} else if interval > 0 && scrapeTimeout > interval {
+			allErrs = append(allErrs, field.Invalid(path.Child("scrapeTimeout"), spec.ScrapeTimeout,
+				fmt.Sprintf("must not be greater than the scrape interval %s", interval)))
+		}
+	}
+
+	alertsPath := path.Child("alerts")
+	for _, alert := range []struct {
+		name string
+		spec kedav1alpha1.AlertSpec
+	}{
+		{"metricsAPIServiceUnavailable", spec.Alerts.MetricsAPIServiceUnavailable},
+		{"scalerErrors", spec.Alerts.ScalerErrors},
+		{"scaledObjectErrors", spec.Alerts.ScaledObjectErrors},
+		{"operatorReconcileErrors", spec.Alerts.OperatorReconcileErrors},
+		{"certificateExpiry", spec.Alerts.CertificateExpiry},
+	} {
+		if alert.spec.RunbookURL == "" {
+			continue
+		}
+		if u, err := url.Parse(alert.spec.RunbookURL); err != nil || !u.IsAbs() {
+			allErrs = append(allErrs, field.Invalid(alertsPath.Child(alert.name, "runbookURL"), alert.spec.RunbookURL, "needs to be an absolute URL"))
+		}
+	}
+
+	dashboardsPath := path.Child("dashboards")
+	if spec.Dashboards.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.Dashboards.Namespace) {
+			allErrs = append(allErrs, field.Invalid(dashboardsPath.Child("namespace"), spec.Dashboards.Namespace, msg))
+		}
+	}
+	allErrs = append(allErrs, metav1validation.ValidateLabels(spec.Dashboards.AdditionalLabels, dashboardsPath.Child("additionalLabels"))...)
+
+	if tlsConfig := spec.TLSConfig; tlsConfig != nil {
+		tlsConfigPath := path.Child("tlsConfig")
+		allErrs = append(allErrs, validateMonitoringKeySelector(tlsConfigPath.Child("ca"), tlsConfig.CA)...)
+		allErrs = append(allErrs, validateMonitoringKeySelector(tlsConfigPath.Child("cert"), tlsConfig.Cert)...)
+		if tlsConfig.Cert != nil && tlsConfig.KeySecret == nil {
+			allErrs = append(allErrs, field.Required(tlsConfigPath.Child("keySecret"), "needs to be set together with cert"))
+		}
+		if tlsConfig.KeySecret != nil && tlsConfig.Cert == nil {
+			allErrs = append(allErrs, field.Required(tlsConfigPath.Child("cert"), "needs to be set together with keySecret"))
+		}
+	}
+
+	for i, relabeling := range spec.Relabelings {
+		relabelingPath := path.Child("relabelings").Index(i)
+		if _, err := regexp.Compile(relabeling.Regex); err != nil {
+			allErrs = append(allErrs, field.Invalid(relabelingPath.Child("regex"), relabeling.Regex, err.Error()))
+		}
+		switch relabeling.Action {
+		case "", "replace", "hashmod", "lowercase", "uppercase", "keepequal", "dropequal":
+			if relabeling.TargetLabel == "" {
+				allErrs = append(allErrs, field.Required(relabelingPath.Child("targetLabel"),
+					fmt.Sprintf("needs to be set for action %q", actionOrDefault(relabeling.Action))))
+			}
+		}
+		if relabeling.Action == "hashmod" && relabeling.Modulus == 0 {
+			allErrs = append(allErrs, field.Required(relabelingPath.Child("modulus"), "needs to be set for action \"hashmod\""))
+		}
+	}
+	return allErrs
+}
+
+// validateMonitoringKeySelector checks that a key of the TLS configuration is taken from either a ConfigMap or a Secret
+func validateMonitoringKeySelector(path *field.Path, selector *kedav1alpha1.MonitoringKeySelector) field.ErrorList {
+	if selector == nil || (selector.ConfigMap == nil) != (selector.Secret == nil) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, selector, "needs exactly one of configMap and secret")}
+}
+
+func actionOrDefault(action string) string {
+	if action == "" {
+		return "replace"
+	}
+	return action
+}
+
+func monitoringWarnings(path *field.Path, spec kedav1alpha1.MonitoringSpec) admission.Warnings {
+	var warnings admission.Warnings
+	if !spec.IsEnabled() && (len(spec.AdditionalLabels) > 0 || spec.Interval != "" || spec.ScrapeTimeout != "" || len(spec.Relabelings) > 0 ||
+		spec.TLSConfig != nil || spec.BearerTokenSecret != nil || spec.Alerts.Enabled || spec.Dashboards.Enabled) {
+		warnings = append(warnings, fmt.Sprintf("%s is false, the other monitoring settings are ignored", path.Child("enabled")))
+	}
+	if spec.Alerts.Enabled && spec.Alerts.CertificateExpiry.IsEnabled() && !spec.Components.IsOLMOperatorEnabled() {
+		warnings = append(warnings, fmt.Sprintf("%s is false, so %s never fires, it is based on a metric of the KEDA OLM operator",
+			path.Child("components", "olmOperator"), path.Child("alerts", "certificateExpiry")))
+	}
+	return warnings
+}
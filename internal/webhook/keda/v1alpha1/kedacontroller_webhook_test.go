@@ -0,0 +1,750 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda-olm-operator/api/keda/v1alpha1"
+	webhookv1alpha1 "github.com/kedacore/keda-olm-operator/internal/webhook/keda/v1alpha1"
+)
+
+const installNamespace = "keda"
+
+func newKedaController() *kedav1alpha1.KedaController {
+	return &kedav1alpha1.KedaController{
+		ObjectMeta: metav1.ObjectMeta{Name: "keda", Namespace: installNamespace},
+	}
+}
+
+var _ = Describe("Defaulting a KedaController", func() {
+	defaulter := &webhookv1alpha1.KedaControllerCustomDefaulter{}
+
+	It("Should fill the documented defaults", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+		kedaController := newKedaController()
+		Expect(defaulter.Default(context.Background(), kedaController)).To(Succeed())
+
+		Expect(kedaController.Spec.Operator.LogLevel).To(Equal("info"))
+		Expect(kedaController.Spec.Operator.LogEncoder).To(Equal("console"))
+		Expect(kedaController.Spec.Operator.LogTimeEncoding).To(Equal("rfc3339"))
+		Expect(kedaController.Spec.MetricsServer.LogLevel).To(Equal("0"))
+		Expect(kedaController.Spec.AdmissionWebhooks.LogLevel).To(Equal("info"))
+		Expect(kedaController.Spec.AdmissionWebhooks.LogEncoder).To(Equal("console"))
+		Expect(kedaController.Spec.AdmissionWebhooks.LogTimeEncoding).To(Equal("rfc3339"))
+	})
+
+	It("Should keep the values set by the user", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+		kedaController := newKedaController()
+		kedaController.Spec.Operator.LogLevel = "debug"
+		kedaController.Spec.MetricsServer.LogLevel = "4"
+		Expect(defaulter.Default(context.Background(), kedaController)).To(Succeed())
+
+		Expect(kedaController.Spec.Operator.LogLevel).To(Equal("debug"))
+		Expect(kedaController.Spec.MetricsServer.LogLevel).To(Equal("4"))
+	})
+})
+
+var _ = Describe("Validating a KedaController", func() {
+	validator := &webhookv1alpha1.KedaControllerCustomValidator{InstallNamespace: installNamespace}
+	replicas := int32(2)
+	singleReplica := int32(1)
+	one := intstr.FromInt32(1)
+
+	invalidData := []struct {
+		context string
+		modify  func(*kedav1alpha1.KedaController)
+		field   string
+	}{
+		{
+			context: "When the KedaController is not named keda",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Name = "my-keda" },
+			field:   "metadata.name",
+		},
+		{
+			context: "When the KedaController is not in the install namespace",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Namespace = "default" },
+			field:   "metadata.namespace",
+		},
+		{
+			context: "When the operator log level is invalid",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Operator.LogLevel = "verbose" },
+			field:   "spec.operator.logLevel",
+		},
+		{
+			context: "When the admission webhooks log encoder is invalid",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.AdmissionWebhooks.LogEncoder = "yaml" },
+			field:   "spec.admissionWebhooks.logEncoder",
+		},
+		{
+			context: "When the operator log time encoding is invalid",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Operator.LogTimeEncoding = "unix" },
+			field:   "spec.operator.logTimeEncoding",
+		},
+		{
+			context: "When the metrics server log level is not an integer",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.MetricsServer.LogLevel = "debug" },
+			field:   "spec.metricsServer.logLevel",
+		},
+		{
+			context: "When an argument contains several values",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.Args = []string{"--foo=bar", "--zap-devel --v=2"}
+			},
+			field: "spec.operator.args[1]",
+		},
+		{
+			context: "When an argument has an empty name",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.MetricsServer.Args = []string{"--=2"} },
+			field:   "spec.metricsServer.args[0]",
+		},
+		{
+			context: "When the audit log format is invalid",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.AuditConfig.LogFormat = "text"
+			},
+			field: "spec.metricsServer.auditConfig.logFormat",
+		},
+		{
+			context: "When audit lifetime arguments are set while logging to stdout",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.AuditConfig.Policy = kedav1alpha1.AuditPolicy{Rules: []auditv1.PolicyRule{{Level: auditv1.LevelMetadata}}}
+				k.Spec.MetricsServer.AuditConfig.AuditLifetime.MaxAge = "2"
+			},
+			field: "spec.metricsServer.auditConfig.lifetime",
+		},
+		{
+			context: "When both minAvailable and maxUnavailable are set in a PodDisruptionBudget",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.Replicas = &replicas
+				k.Spec.Operator.PodDisruptionBudget = &kedav1alpha1.PodDisruptionBudgetSpec{MinAvailable: &one, MaxUnavailable: &one}
+			},
+			field: "spec.operator.podDisruptionBudget",
+		},
+		{
+			context: "When an env variable has no name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.AdmissionWebhooks.Env = []corev1.EnvVar{{Value: "value"}}
+			},
+			field: "spec.admissionWebhooks.env[0].name",
+		},
+		{
+			context: "When an envFrom source sets no reference",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.EnvFrom = []corev1.EnvFromSource{{Prefix: "KEDA_"}}
+			},
+			field: "spec.operator.envFrom[0]",
+		},
+		{
+			context: "When the HTTP timeout is negative",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.HTTPTimeout = &metav1.Duration{Duration: -time.Second}
+			},
+			field: "spec.metricsServer.httpTimeout",
+		},
+		{
+			context: "When the profiling bind address is malformed",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Operator.ProfilingBindAddress = "8082" },
+			field:   "spec.operator.profilingBindAddress",
+		},
+		{
+			context: "When the profiling bind address uses a port of the component",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.AdmissionWebhooks.ProfilingBindAddress = ":9443" },
+			field:   "spec.admissionWebhooks.profilingBindAddress",
+		},
+		{
+			context: "When the profiling bind address uses the secure port of the Metrics Server",
+			modify: func(k *kedav1alpha1.KedaController) {
+				port := int32(7443)
+				k.Spec.MetricsServer.SecurePort = &port
+				k.Spec.MetricsServer.ProfilingBindAddress = "localhost:7443"
+			},
+			field: "spec.metricsServer.profilingBindAddress",
+		},
+		{
+			context: "When a CA directory is not an absolute path",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Operator.CADirs = []string{"certs/ca"} },
+			field:   "spec.operator.caDirs[0]",
+		},
+		{
+			context: "When the metrics service address has no port",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.GRPC.MetricsServiceAddress = "keda-operator.keda.svc"
+			},
+			field: "spec.metricsServer.grpc.metricsServiceAddress",
+		},
+		{
+			context: "When the image registry mirror has a scheme",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.ImageRegistryMirror = "https://mirror.example.com" },
+			field:   "spec.imageRegistryMirror",
+		},
+		{
+			context: "When an image contains whitespace",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.Image = "ghcr.io/kedacore/keda-metrics-apiserver: 2.17.0"
+			},
+			field: "spec.metricsServer.image",
+		},
+		{
+			context: "When a volume uses the name of a volume of the component",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.Volumes = []corev1.Volume{{Name: "certificates"}}
+			},
+			field: "spec.operator.volumes[0].name",
+		},
+		{
+			context: "When a volume uses the name of a CA bundle volume",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.Volumes = []corev1.Volume{{Name: "gcp-credentials"}, {Name: "cabundle1"}}
+			},
+			field: "spec.operator.volumes[1].name",
+		},
+		{
+			context: "When a sidecar uses the name of the component container",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.Sidecars = []corev1.Container{{Name: "keda-metrics-apiserver", Image: "busybox"}}
+			},
+			field: "spec.metricsServer.sidecars[0].name",
+		},
+		{
+			context: "When init containers share a name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.AdmissionWebhooks.InitContainers = []corev1.Container{{Name: "init", Image: "busybox"}, {Name: "init", Image: "busybox"}}
+			},
+			field: "spec.admissionWebhooks.initContainers[1].name",
+		},
+		{
+			context: "When a sidecar has no image",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.Sidecars = []corev1.Container{{Name: "log-shipper"}}
+			},
+			field: "spec.operator.sidecars[0].image",
+		},
+		{
+			context: "When a Localhost seccomp profile has no localhostProfile",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.ContainerSecurityContext = &corev1.SecurityContext{
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost},
+				}
+			},
+			field: "spec.operator.containerSecurityContext.seccompProfile.localhostProfile",
+		},
+		{
+			context: "When a probe override has a negative value",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.ReadinessProbe = &corev1.Probe{InitialDelaySeconds: -5}
+			},
+			field: "spec.metricsServer.readinessProbe.initialDelaySeconds",
+		},
+		{
+			context: "When a liveness probe override has a success threshold above 1",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.AdmissionWebhooks.LivenessProbe = &corev1.Probe{SuccessThreshold: 3}
+			},
+			field: "spec.admissionWebhooks.livenessProbe.successThreshold",
+		},
+		{
+			context: "When the scrape timeout is greater than the default scrape interval",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Monitoring.ScrapeTimeout = "90s" },
+			field:   "spec.monitoring.scrapeTimeout",
+		},
+		{
+			context: "When a replace relabeling has no target label",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Relabelings = []kedav1alpha1.RelabelConfig{{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}}}
+			},
+			field: "spec.monitoring.relabelings[0].targetLabel",
+		},
+		{
+			context: "When a hashmod relabeling has no modulus",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Relabelings = []kedav1alpha1.RelabelConfig{{Action: "hashmod", TargetLabel: "shard"}}
+			},
+			field: "spec.monitoring.relabelings[0].modulus",
+		},
+		{
+			context: "When the CA of the scrapes names both a ConfigMap and a Secret",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.TLSConfig = &kedav1alpha1.MonitoringTLSConfig{CA: &kedav1alpha1.MonitoringKeySelector{
+					ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "prometheus-ca"}, Key: "ca.crt"},
+					Secret:    &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "prometheus-ca"}, Key: "ca.crt"},
+				}}
+			},
+			field: "spec.monitoring.tlsConfig.ca",
+		},
+		{
+			context: "When the client certificate of the scrapes has no key",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.TLSConfig = &kedav1alpha1.MonitoringTLSConfig{Cert: &kedav1alpha1.MonitoringKeySelector{
+					Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "prometheus-client"}, Key: "tls.crt"},
+				}}
+			},
+			field: "spec.monitoring.tlsConfig.keySecret",
+		},
+		{
+			context: "When the runbook URL of an alert is not absolute",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Alerts.ScalerErrors.RunbookURL = "runbooks/keda-scaler-errors"
+			},
+			field: "spec.monitoring.alerts.scalerErrors.runbookURL",
+		},
+		{
+			context: "When the dashboards namespace is not a valid namespace name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Monitoring.Dashboards.Namespace = "Grafana_Dashboards"
+			},
+			field: "spec.monitoring.dashboards.namespace",
+		},
+		{
+			context: "When a watched namespace is not a valid namespace name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.WatchNamespaces = []string{"apps", "team_a"}
+			},
+			field: "spec.watchNamespaces[1]",
+		},
+		{
+			context: "When the namespace selector has an invalid operator",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "keda.sh/watched", Operator: "Equals", Values: []string{"true"}},
+				}}
+			},
+			field: "spec.namespaceSelector.matchExpressions[0].operator",
+		},
+		{
+			context: "When the proxy is not an http or https URL",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.Proxy.HTTPSProxy = "proxy.example.com:3128" },
+			field:   "spec.proxy.httpsProxy",
+		},
+		{
+			context: "When the custom TLS profile has no cipher supported by KEDA Metrics Server",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.TLSProfile = &configv1.TLSSecurityProfile{
+					Type: configv1.TLSProfileCustomType,
+					Custom: &configv1.CustomTLSProfile{TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"DHE-RSA-AES128-GCM-SHA256"},
+						MinTLSVersion: configv1.VersionTLS12,
+					}},
+				}
+			},
+			field: "spec.tlsProfile.custom.ciphers",
+		},
+		{
+			context: "When the AWS pod identity is not an IAM role ARN",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.PodIdentity.AWS = &kedav1alpha1.AWSPodIdentitySpec{RoleARN: "keda-operator"}
+			},
+			field: "spec.podIdentity.aws.roleArn",
+		},
+		{
+			context: "When two pod identities use the same ClusterTriggerAuthentication",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.PodIdentity.AWS = &kedav1alpha1.AWSPodIdentitySpec{
+					RoleARN: "arn:aws:iam::123456789012:role/keda-operator", ClusterTriggerAuthentication: "cloud",
+				}
+				k.Spec.PodIdentity.GCP = &kedav1alpha1.GCPPodIdentitySpec{
+					ServiceAccountEmail:          "keda@project.iam.gserviceaccount.com",
+					Audience:                     "//iam.googleapis.com/projects/123456/locations/global/workloadIdentityPools/pool/providers/provider",
+					ClusterTriggerAuthentication: "cloud",
+				}
+			},
+			field: "spec.podIdentity.gcp.clusterTriggerAuthentication",
+		},
+		{
+			context: "When the Thanos Querier ClusterTriggerAuthentication is used by a pod identity",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.PodIdentity.AWS = &kedav1alpha1.AWSPodIdentitySpec{
+					RoleARN: "arn:aws:iam::123456789012:role/keda-operator", ClusterTriggerAuthentication: "keda-prometheus",
+				}
+				k.Spec.OpenShiftMonitoring = kedav1alpha1.OpenShiftMonitoringSpec{Enabled: true, ClusterTriggerAuthentication: "keda-prometheus"}
+			},
+			field: "spec.openshiftMonitoring.clusterTriggerAuthentication",
+		},
+		{
+			context: "When a CA source references neither a ConfigMap nor a Secret",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.CASources = []kedav1alpha1.CASource{{}}
+			},
+			field: "spec.metricsServer.caSources[0]",
+		},
+		{
+			context: "When a CA source references both a ConfigMap and a Secret",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.CASources = []kedav1alpha1.CASource{{ConfigMap: "corporate-ca", Secret: "vault-ca"}}
+			},
+			field: "spec.operator.caSources[0]",
+		},
+		{
+			context: "When a CA source references an invalid Secret name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.AdmissionWebhooks.CASources = []kedav1alpha1.CASource{{Secret: "Vault_CA"}}
+			},
+			field: "spec.admissionWebhooks.caSources[0].secret",
+		},
+		{
+			context: "When KEDA Operator rotates the certificates issued by cert-manager",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderCertManager
+				certRotation := true
+				k.Spec.Operator.CertRotation = &certRotation
+			},
+			field: "spec.operator.certRotation",
+		},
+		{
+			context: "When the cert-manager issuer has an invalid name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.CertManager.IssuerRef = &kedav1alpha1.CertManagerIssuerRef{Name: "Corporate_CA"}
+			},
+			field: "spec.certificates.certManager.issuerRef.name",
+		},
+		{
+			context: "When the certificate is renewed before its lifetime starts",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.CertManager.Duration = &metav1.Duration{Duration: 24 * time.Hour}
+				k.Spec.Certificates.CertManager.RenewBefore = &metav1.Duration{Duration: 48 * time.Hour}
+			},
+			field: "spec.certificates.certManager.renewBefore",
+		},
+		{
+			context: "When the user-provided certificates miss the Secret of KEDA Metrics Server",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderUserProvided
+				k.Spec.Certificates.UserProvided = kedav1alpha1.UserProvidedCertificatesSpec{
+					AdmissionWebhooksSecret: "keda-webhooks-tls",
+					GRPCSecret:              "keda-grpc-tls",
+					CABundle:                kedav1alpha1.CASource{ConfigMap: "keda-ca"},
+				}
+			},
+			field: "spec.certificates.userProvided.metricsServerSecret",
+		},
+		{
+			context: "When the user-provided certificates miss the CA bundle",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderUserProvided
+				k.Spec.Certificates.UserProvided = kedav1alpha1.UserProvidedCertificatesSpec{
+					MetricsServerSecret:     "keda-metrics-tls",
+					AdmissionWebhooksSecret: "keda-webhooks-tls",
+					GRPCSecret:              "keda-grpc-tls",
+				}
+			},
+			field: "spec.certificates.userProvided.caBundle",
+		},
+		{
+			context: "When the user-provided gRPC Secret has an invalid name",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.UserProvided.GRPCSecret = "KEDA_gRPC"
+			},
+			field: "spec.certificates.userProvided.grpcSecret",
+		},
+		{
+			context: "When the user-provided gRPC Secret is the one of the generated certificates",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderUserProvided
+				k.Spec.Certificates.UserProvided = kedav1alpha1.UserProvidedCertificatesSpec{
+					MetricsServerSecret:     "keda-metrics-tls",
+					AdmissionWebhooksSecret: "keda-webhooks-tls",
+					GRPCSecret:              "kedaorg-certs",
+					CABundle:                kedav1alpha1.CASource{ConfigMap: "keda-ca"},
+				}
+			},
+			field: "spec.certificates.userProvided.grpcSecret",
+		},
+		{
+			context: "When the user-provided CA bundle is the Secret of the generated certificates",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.UserProvided.CABundle = kedav1alpha1.CASource{Secret: "kedaorg-certs"}
+			},
+			field: "spec.certificates.userProvided.caBundle.secret",
+		},
+		{
+			context: "When KEDA Operator rotates the user-provided certificates",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderUserProvided
+				k.Spec.Certificates.UserProvided = kedav1alpha1.UserProvidedCertificatesSpec{
+					MetricsServerSecret:     "keda-metrics-tls",
+					AdmissionWebhooksSecret: "keda-webhooks-tls",
+					GRPCSecret:              "keda-grpc-tls",
+					CABundle:                kedav1alpha1.CASource{Secret: "keda-ca"},
+				}
+				certRotation := true
+				k.Spec.Operator.CertRotation = &certRotation
+			},
+			field: "spec.operator.certRotation",
+		},
+	}
+	for _, tt := range invalidData {
+		Context(tt.context, func() {
+			It("Should reject the KedaController with a field error", func() {
+				if testType != "unit" {
+					Skip("test.type isn't 'unit'")
+				}
+				kedaController := newKedaController()
+				tt.modify(kedaController)
+
+				_, err := validator.ValidateCreate(context.Background(), kedaController)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(tt.field))
+
+				_, err = validator.ValidateUpdate(context.Background(), newKedaController(), kedaController)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	}
+
+	It("Should report several field errors in the same order", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+		kedaController := newKedaController()
+		kedaController.Spec.MetricsServer.AuditConfig.AuditLifetime = kedav1alpha1.AuditLifetime{MaxAge: "a", MaxBackup: "b", MaxSize: "c"}
+
+		_, err := validator.ValidateCreate(context.Background(), kedaController)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(MatchRegexp("lifetime.maxAge.*lifetime.maxBackup.*lifetime.maxSize"))
+		for i := 0; i < 10; i++ {
+			_, again := validator.ValidateCreate(context.Background(), kedaController)
+			Expect(again.Error()).To(Equal(err.Error()))
+		}
+	})
+
+	warningData := []struct {
+		context string
+		modify  func(*kedav1alpha1.KedaController)
+		warning string
+	}{
+		{
+			context: "When a PodDisruptionBudget is set for a single replica",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.Replicas = &singleReplica
+				k.Spec.MetricsServer.PodDisruptionBudget = &kedav1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &one}
+			},
+			warning: "spec.metricsServer.podDisruptionBudget",
+		},
+		{
+			context: "When the audit config is set without a policy",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.MetricsServer.AuditConfig.LogFormat = "json" },
+			warning: "spec.metricsServer.auditConfig.policy",
+		},
+		{
+			context: "When an argument overrides a dedicated field",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Operator.LogLevel = "info"
+				k.Spec.Operator.Args = []string{"--zap-log-level=debug"}
+			},
+			warning: "spec.operator.args[0]",
+		},
+		{
+			context: "When an env variable overrides the watched namespace",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.WatchNamespaces = []string{"apps"}
+				k.Spec.Operator.Env = []corev1.EnvVar{{Name: "WATCH_NAMESPACE", Value: ""}}
+			},
+			warning: "spec.operator.env[0]",
+		},
+		{
+			context: "When the deprecated watchNamespace is set",
+			modify:  func(k *kedav1alpha1.KedaController) { k.Spec.WatchNamespace = "apps" },
+			warning: "spec.watchNamespace is deprecated",
+		},
+		{
+			context: "When an env variable overrides the proxy",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Proxy.HTTPProxy = "http://proxy.example.com:3128"
+				k.Spec.Operator.Env = []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other.example.com:3128"}}
+			},
+			warning: "spec.operator.env[0]",
+		},
+		{
+			context: "When a ServiceAccount annotation overrides the pod identity",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.PodIdentity.AWS = &kedav1alpha1.AWSPodIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/keda-operator"}
+				k.Spec.ServiceAccount.Annotations = map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/other"}
+			},
+			warning: "spec.serviceAccount.annotations[eks.amazonaws.com/role-arn]",
+		},
+		{
+			context: "When the Thanos Querier ClusterTriggerAuthentication is named while disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.OpenShiftMonitoring.ClusterTriggerAuthentication = "keda-prometheus"
+			},
+			warning: "spec.openshiftMonitoring.clusterTriggerAuthentication is ignored",
+		},
+		{
+			context: "When env overrides the trusted CAs of KEDA Metrics Server",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.CASources = []kedav1alpha1.CASource{{Secret: "vault-ca"}}
+				k.Spec.MetricsServer.Env = []corev1.EnvVar{{Name: "SSL_CERT_DIR", Value: "/certs"}}
+			},
+			warning: "spec.metricsServer.env[0] overrides the value '/etc/ssl/certs:/custom/ca0'",
+		},
+		{
+			context: "When cert-manager is configured for another certificates provider",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderKedaBuiltin
+				k.Spec.Certificates.CertManager.IssuerRef = &kedav1alpha1.CertManagerIssuerRef{Name: "corporate-ca", Kind: "ClusterIssuer"}
+			},
+			warning: "spec.certificates.certManager is ignored",
+		},
+		{
+			context: "When user-provided certificates are configured for another certificates provider",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.Certificates.Provider = kedav1alpha1.CertificatesProviderCertManager
+				k.Spec.Certificates.UserProvided.GRPCSecret = "keda-grpc-tls"
+			},
+			warning: "spec.certificates.userProvided is ignored",
+		},
+		{
+			context: "When an argument overrides the TLS profile",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.TLSProfile = &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType}
+				k.Spec.MetricsServer.Args = []string{"--tls-min-version=VersionTLS12"}
+			},
+			warning: "spec.metricsServer.args[0]",
+		},
+		{
+			context: "When the Kubernetes client burst is lower than the QPS",
+			modify: func(k *kedav1alpha1.KedaController) {
+				qps, burst := int32(50), int32(10)
+				k.Spec.Operator.KubeAPIQPS = &qps
+				k.Spec.Operator.KubeAPIBurst = &burst
+			},
+			warning: "spec.operator.kubeAPIBurst",
+		},
+		{
+			context: "When an argument overrides a runtime setting",
+			modify: func(k *kedav1alpha1.KedaController) {
+				qps := int32(50)
+				k.Spec.AdmissionWebhooks.KubeAPIQPS = &qps
+				k.Spec.AdmissionWebhooks.Args = []string{"--kube-api-qps=20"}
+			},
+			warning: "spec.admissionWebhooks.args[0]",
+		},
+		{
+			context: "When an env variable overrides the HTTP timeout",
+			modify: func(k *kedav1alpha1.KedaController) {
+				k.Spec.MetricsServer.HTTPTimeout = &metav1.Duration{Duration: 5 * time.Second}
+				k.Spec.MetricsServer.Env = []corev1.EnvVar{{Name: "KEDA_HTTP_DEFAULT_TIMEOUT", Value: "1000"}}
+			},
+			warning: "spec.metricsServer.env[0]",
+		},
+		{
+			context: "When a container runs privileged",
+			modify: func(k *kedav1alpha1.KedaController) {
+				privileged := true
+				k.Spec.Operator.ContainerSecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+			},
+			warning: "spec.operator.containerSecurityContext.privileged",
+		},
+		{
+			context: "When the metrics server is disabled while the operator rotates certificates",
+			modify: func(k *kedav1alpha1.KedaController) {
+				disabled := false
+				k.Spec.MetricsServer.Enabled = &disabled
+			},
+			warning: "spec.operator.certRotation",
+		},
+		{
+			context: "When monitoring settings are set while monitoring is disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				disabled := false
+				k.Spec.Monitoring.Enabled = &disabled
+				k.Spec.Monitoring.Interval = "30s"
+			},
+			warning: "spec.monitoring.enabled",
+		},
+		{
+			context: "When the certificate expiry alert is enabled without the operator PodMonitor",
+			modify: func(k *kedav1alpha1.KedaController) {
+				disabled := false
+				k.Spec.Monitoring.Alerts.Enabled = true
+				k.Spec.Monitoring.Components.OLMOperator = &disabled
+			},
+			warning: "spec.monitoring.alerts.certificateExpiry",
+		},
+		{
+			context: "When dashboards are enabled while monitoring is disabled",
+			modify: func(k *kedav1alpha1.KedaController) {
+				disabled := false
+				k.Spec.Monitoring.Enabled = &disabled
+				k.Spec.Monitoring.Dashboards.Enabled = true
+			},
+			warning: "spec.monitoring.enabled",
+		},
+	}
+	for _, tt := range warningData {
+		Context(tt.context, func() {
+			It("Should accept the KedaController with a warning", func() {
+				if testType != "unit" {
+					Skip("test.type isn't 'unit'")
+				}
+				kedaController := newKedaController()
+				tt.modify(kedaController)
+
+				warnings, err := validator.ValidateCreate(context.Background(), kedaController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ContainElement(ContainSubstring(tt.warning)))
+			})
+		})
+	}
+
+	It("Should accept a valid KedaController without warnings", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+		kedaController := newKedaController()
+		kedaController.Spec.Operator.LogLevel = "debug"
+		kedaController.Spec.Operator.Args = []string{"--kube-api-qps=40"}
+		kedaController.Spec.MetricsServer.LogLevel = "2"
+		kedaController.Spec.MetricsServer.Replicas = &replicas
+		kedaController.Spec.MetricsServer.AuditConfig = kedav1alpha1.AuditConfig{
+			LogFormat:            "json",
+			LogOutputVolumeClaim: "audit-pvc",
+			Policy:               kedav1alpha1.AuditPolicy{Rules: []auditv1.PolicyRule{{Level: auditv1.LevelMetadata}}},
+			AuditLifetime:        kedav1alpha1.AuditLifetime{MaxAge: "2"},
+		}
+
+		warnings, err := validator.ValidateCreate(context.Background(), kedaController)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("Should return an Invalid API error", func() {
+		if testType != "unit" {
+			Skip("test.type isn't 'unit'")
+		}
+		kedaController := newKedaController()
+		kedaController.Name = "my-keda"
+
+		_, err := validator.ValidateCreate(context.Background(), kedaController)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	})
+})
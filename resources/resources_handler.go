@@ -9,16 +9,27 @@ import (
 
 const resourcesPath = "keda.yaml"
 const olmResourcesPath = "keda-olm-operator.yaml"
+const dashboardsResourcesPath = "keda-dashboards.yaml"
+
+// LastConfigID is the annotation manifestival tracked the applied resources with before the operator
+// switched to server-side apply, it is removed from existing resources when they are applied again
 const LastConfigID = "olm-operator.keda.sh/last-applied-configuration"
 
 func GetResourcesManifest() (mf.Manifest, error) {
 	_, path, _, _ := runtime.Caller(0)
 	fullPath := filepath.Join(filepath.Dir(path), resourcesPath)
 	olmFullPath := filepath.Join(filepath.Dir(path), olmResourcesPath)
-	kedamf, err := mf.NewManifest(fullPath, mf.UseLastAppliedConfigAnnotation(LastConfigID))
+	kedamf, err := mf.NewManifest(fullPath)
 	if err != nil {
 		return kedamf, err
 	}
-	operatormf, err := mf.NewManifest(olmFullPath, mf.UseLastAppliedConfigAnnotation(LastConfigID))
+	operatormf, err := mf.NewManifest(olmFullPath)
 	return kedamf.Append(operatormf), err
 }
+
+// GetDashboardsManifest returns the ConfigMaps holding the Grafana dashboards of KEDA, they are installed
+// only when enabled in the KedaController
+func GetDashboardsManifest() (mf.Manifest, error) {
+	_, path, _, _ := runtime.Caller(0)
+	return mf.NewManifest(filepath.Join(filepath.Dir(path), dashboardsResourcesPath))
+}